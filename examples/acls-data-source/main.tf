@@ -0,0 +1,14 @@
+data "confluentacl_acls" "default" {
+  rest_endpoint        = "https://XXXXXXXXXX.eastus2.azure.confluent.cloud"
+  cluster_id           = "lkc-123abc"
+  service_account_name = "my-service-account"
+
+  // Every filter is optional
+  resource_type = "TOPIC"
+  permission    = "ALLOW"
+}
+
+output "acls" {
+  value = data.confluentacl_acls.default.acls
+  // Outputs the ACLs of my-service-account allowing access to topics
+}
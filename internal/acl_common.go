@@ -0,0 +1,419 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	principalRegex            = regexp.MustCompile(`^(User:(\*|\d+|(sa|u|pool)-\S+)|Group:\S+)$`)
+	durationRegex             = regexp.MustCompile(`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`)
+	errServiceAccountNotFound = errors.New("could not find service account")
+
+	aclResourceTypes = []string{"TOPIC", "GROUP", "CLUSTER", "TRANSACTIONAL_ID", "DELEGATION_TOKEN"}
+	aclPatternTypes  = []string{"MATCH", "LITERAL", "PREFIXED"}
+	aclOperations    = []string{"ALL", "READ", "WRITE", "CREATE", "DELETE", "ALTER", "DESCRIBE", "CLUSTER_ACTION", "DESCRIBE_CONFIGS", "ALTER_CONFIGS", "IDEMPOTENT_WRITE"}
+	aclPermissions   = []string{"ALLOW", "DENY"}
+
+	// Filters also accept ANY, matching every value
+	aclFilterResourceTypes = append([]string{client.AclAny}, aclResourceTypes...)
+	aclFilterPatternTypes  = append([]string{client.AclAny}, aclPatternTypes...)
+	aclFilterOperations    = append([]string{client.AclAny}, aclOperations...)
+	aclFilterPermissions   = append([]string{client.AclAny}, aclPermissions...)
+)
+
+// aclPropagationPollInterval is the time between listings while waiting for created ACLs to be visible
+var aclPropagationPollInterval = time.Second
+
+const (
+	defaultAclPropagationTimeout = 30 * time.Second
+	principalValidationMessage   = "Value must be a kafka principal, e.g. User:sa-123abc, User:pool-123abc, User:u-123abc, User:* or Group:my-group"
+)
+
+// resolveServiceAccountPrincipal returns the resource id principal (User:sa-123abc) of the service account with the given name
+func resolveServiceAccountPrincipal(c *client.Client, saName string) (string, error) {
+	serviceAccount, err := c.GetServiceAccount(saName)
+	if err != nil {
+		return "", err
+	}
+	if serviceAccount == nil {
+		return "", fmt.Errorf("%w with name %s", errServiceAccountNotFound, saName)
+	}
+	return "User:" + serviceAccount.Id, nil
+}
+
+// removeResourceOfDeletedServiceAccount drops a resource whose service account was deleted outside terraform from the
+// state, with a warning telling why it's planned to be created again
+func removeResourceOfDeletedServiceAccount(ctx context.Context, resp *resource.ReadResponse, resourceDescription, saName string) {
+	resp.Diagnostics.AddWarning(
+		"Service account deleted outside terraform",
+		fmt.Sprintf("Service account %s of %s no longer exists, along with its ACLs. The resource is removed from the state and will be created again if still configured.", saName, resourceDescription),
+	)
+	resp.State.RemoveResource(ctx)
+}
+
+// resolveConfiguredPrincipal returns the principal of resources accepting either service_account_name or principal
+func resolveConfiguredPrincipal(c *client.Client, serviceAccountName, principal types.String) (string, error) {
+	if serviceAccountName.IsNull() {
+		return principal.ValueString(), nil
+	}
+	return resolveServiceAccountPrincipal(c, serviceAccountName.ValueString())
+}
+
+// checkPlannedAclTarget fails the plan when the cluster of a resource doesn't exist, and warns when its service account
+// doesn't exist, so a typo is reported before any resource is applied. Values unknown at plan time are checked at
+// apply.
+func checkPlannedAclTarget(ctx context.Context, c *client.Client, plan tfsdk.Plan, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+	var serviceAccountName, restEndpoint, clusterId types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("service_account_name"), &serviceAccountName)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("rest_endpoint"), &restEndpoint)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("cluster_id"), &clusterId)...)
+	if diags.HasError() {
+		return
+	}
+	checkPlannedServiceAccount(c, serviceAccountName, diags)
+
+	if restEndpoint.IsNull() || restEndpoint.IsUnknown() || clusterId.IsNull() || clusterId.IsUnknown() {
+		return
+	}
+	err := c.CheckKafkaCluster(restEndpoint.ValueString(), clusterId.ValueString())
+	if errors.Is(err, client.ErrKafkaClusterNotFound) {
+		diags.AddAttributeError(path.Root("cluster_id"), "Kafka cluster not found",
+			fmt.Sprintf("Cluster %s isn't served by rest endpoint %s", clusterId.ValueString(), restEndpoint.ValueString()))
+	} else if err != nil {
+		diags.AddAttributeError(path.Root("rest_endpoint"), "Kafka cluster unreachable", err.Error())
+	}
+}
+
+// checkPlannedServiceAccount warns when the service account name is known and matches no service account. It isn't an
+// error, as the service account may be created by the same apply, e.g. with the display_name of a
+// confluent_service_account resource, which is known at plan time. Apply fails if it still doesn't exist.
+func checkPlannedServiceAccount(c *client.Client, serviceAccountName types.String, diags *diag.Diagnostics) {
+	if c == nil || serviceAccountName.IsNull() || serviceAccountName.IsUnknown() {
+		return
+	}
+	serviceAccount, err := c.GetServiceAccount(serviceAccountName.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("service_account_name"), "Failed to list service accounts", err.Error())
+		return
+	}
+	if serviceAccount == nil {
+		diags.AddAttributeWarning(path.Root("service_account_name"), "Service account not found",
+			fmt.Sprintf("There's no service account named %s yet. Apply fails unless it's created first, e.g. by another resource of this configuration.", serviceAccountName.ValueString()))
+	}
+}
+
+// AclRuleModel is an ACL without its principal and cluster, as used in nested blocks of resources managing many ACLs
+type AclRuleModel struct {
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	PatternType  types.String `tfsdk:"pattern_type"`
+	Host         types.String `tfsdk:"host"`
+	Operation    types.String `tfsdk:"operation"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+func newAclRule(resourceType, resourceName, patternType, operation, permission string) AclRuleModel {
+	return AclRuleModel{
+		ResourceType: types.StringValue(resourceType),
+		ResourceName: types.StringValue(resourceName),
+		PatternType:  types.StringValue(patternType),
+		Host:         types.StringValue("*"),
+		Operation:    types.StringValue(operation),
+		Permission:   types.StringValue(permission),
+	}
+}
+
+func aclRuleFromAcl(acl client.ACLListResponse) AclRuleModel {
+	return AclRuleModel{
+		ResourceType: types.StringValue(acl.ResourceType),
+		ResourceName: types.StringValue(acl.ResourceName),
+		PatternType:  types.StringValue(acl.PatternType),
+		Host:         types.StringValue(acl.Host),
+		Operation:    types.StringValue(acl.Operation),
+		Permission:   types.StringValue(acl.Permission),
+	}
+}
+
+func (m AclRuleModel) aclRequest(principal string) *client.ACLRequest {
+	return &client.ACLRequest{
+		Principal:    principal,
+		ResourceType: m.ResourceType.ValueString(),
+		ResourceName: m.ResourceName.ValueString(),
+		PatternType:  m.PatternType.ValueString(),
+		Host:         m.Host.ValueString(),
+		Operation:    m.Operation.ValueString(),
+		Permission:   m.Permission.ValueString(),
+	}
+}
+
+func (m AclRuleModel) key() string {
+	return aclRequestKey(m.aclRequest(""))
+}
+
+// aclRequestKey identifies an ACL regardless of its principal
+func aclRequestKey(acl *client.ACLRequest) string {
+	return strings.Join([]string{acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Host, acl.Operation, acl.Permission}, "#")
+}
+
+func aclRuleComputedAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"resource_type": schema.StringAttribute{Computed: true},
+		"resource_name": schema.StringAttribute{Computed: true},
+		"pattern_type":  schema.StringAttribute{Computed: true},
+		"host":          schema.StringAttribute{Computed: true},
+		"operation":     schema.StringAttribute{Computed: true},
+		"permission":    schema.StringAttribute{Computed: true},
+	}
+}
+
+func aclRuleNestedAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"resource_type": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.OneOf(aclResourceTypes...)},
+		},
+		"resource_name": schema.StringAttribute{
+			Required: true,
+		},
+		"pattern_type": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.OneOf(aclPatternTypes...)},
+		},
+		"host": schema.StringAttribute{
+			Required: true,
+		},
+		"operation": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.OneOf(aclOperations...)},
+		},
+		"permission": schema.StringAttribute{
+			Required:   true,
+			Validators: []validator.String{stringvalidator.OneOf(aclPermissions...)},
+		},
+	}
+}
+
+// listPrincipalAcls lists the ACLs of a principal in the cluster under every form the principal can be listed with
+func listPrincipalAcls(c *client.Client, restEndpoint, clusterId, principal string) ([]client.ACLListResponse, error) {
+	principals, err := c.PrincipalAliases(principal)
+	if err != nil {
+		return nil, err
+	}
+	var aclsFound []client.ACLListResponse
+	for _, principal := range principals {
+		acls, err := c.ListSpecificACLs(restEndpoint, clusterId, &client.ACLRequest{Principal: principal})
+		if err != nil {
+			return nil, err
+		}
+		aclsFound = append(aclsFound, acls...)
+	}
+	sortAcls(aclsFound)
+	return aclsFound, nil
+}
+
+// aclRulesFromAcls converts listed ACLs to rules, merging ACLs listed under different forms of the same principal
+func aclRulesFromAcls(acls []client.ACLListResponse) []AclRuleModel {
+	rules := make([]AclRuleModel, 0, len(acls))
+	seen := make(map[string]bool, len(acls))
+	for _, acl := range acls {
+		rule := aclRuleFromAcl(acl)
+		if !seen[rule.key()] {
+			seen[rule.key()] = true
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// filterAclsByRules returns the listed ACLs matching one of the rules
+func filterAclsByRules(acls []client.ACLListResponse, rules []AclRuleModel) []client.ACLListResponse {
+	ruleKeys := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		ruleKeys[rule.key()] = true
+	}
+	var filtered []client.ACLListResponse
+	for _, acl := range acls {
+		if ruleKeys[aclRuleFromAcl(acl).key()] {
+			filtered = append(filtered, acl)
+		}
+	}
+	return filtered
+}
+
+// reconcileAcls creates the desired rules missing from the existing ACLs and deletes the existing ACLs that aren't
+// desired. Existing ACLs are deleted with the principal form they're listed with.
+func reconcileAcls(ctx context.Context, c *client.Client, restEndpoint, clusterId, principal string, desired []AclRuleModel, existing []client.ACLListResponse) error {
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		desiredKeys[rule.key()] = true
+	}
+	existingKeys := make(map[string]bool, len(existing))
+	for _, acl := range existing {
+		aclRequest := aclRuleFromAcl(acl).aclRequest(acl.Principal)
+		existingKeys[aclRequestKey(aclRequest)] = true
+		if desiredKeys[aclRequestKey(aclRequest)] {
+			continue
+		}
+		if err := c.DeleteAcl(ctx, restEndpoint, clusterId, aclRequest); err != nil {
+			return err
+		}
+	}
+	for _, rule := range desired {
+		if existingKeys[rule.key()] {
+			continue
+		}
+		if err := c.CreateACL(ctx, restEndpoint, clusterId, rule.aclRequest(principal)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyAclRuleChanges creates the rules added since the previous rules and deletes the previous rules removed from
+// the desired ones. Rules present in both are left untouched, as are ACLs of the principal that were never rules.
+func applyAclRuleChanges(ctx context.Context, c *client.Client, restEndpoint, clusterId, principal string, desired, previous []AclRuleModel) error {
+	existingAcls := []client.ACLListResponse{}
+	if len(previous) > 0 {
+		aclsFound, err := listPrincipalAcls(c, restEndpoint, clusterId, principal)
+		if err != nil {
+			return err
+		}
+		existingAcls = filterAclsByRules(aclsFound, previous)
+	}
+	return reconcileAcls(ctx, c, restEndpoint, clusterId, principal, desired, existingAcls)
+}
+
+// checkExistingAclRules fails for the rules added since the previous rules whose ACL already exists, unless
+// adoptExisting is set. Creating an existing ACL succeeds, which would leave two owners deleting it independently.
+func checkExistingAclRules(c *client.Client, restEndpoint, clusterId, principal string, desired, previous []AclRuleModel, adoptExisting types.Bool, diags *diag.Diagnostics) {
+	previousKeys := make(map[string]bool, len(previous))
+	for _, rule := range previous {
+		previousKeys[rule.key()] = true
+	}
+	for _, rule := range desired {
+		if previousKeys[rule.key()] {
+			continue
+		}
+		aclsFound, _, err := findModelAcls(c, &AclResourceModel{
+			RestEndpoint: types.StringValue(restEndpoint),
+			ClusterId:    types.StringValue(clusterId),
+			Principal:    types.StringValue(principal),
+			ResourceType: rule.ResourceType,
+			ResourceName: rule.ResourceName,
+			PatternType:  rule.PatternType,
+			Host:         rule.Host,
+			Operation:    rule.Operation,
+			Permission:   rule.Permission,
+		})
+		if err != nil {
+			diags.AddError("Failure to read all acls in cluster", err.Error())
+			return
+		}
+		if len(aclsFound) > 0 && !adoptExisting.ValueBool() {
+			diags.AddError(
+				"ACL already exists",
+				fmt.Sprintf("ACL %s of principal %s already exists in cluster %s, it may be managed by another terraform workspace that would delete it regardless of this resource. "+
+					"Remove the rule, or set adopt_existing = true to take ownership of it.", rule.key(), principal, clusterId),
+			)
+		}
+	}
+}
+
+// sortAcls orders ACLs by principal and ACL tuple, so results don't change between reads
+func sortAcls(acls []client.ACLListResponse) {
+	sort.Slice(acls, func(i, j int) bool {
+		a, b := acls[i], acls[j]
+		for _, pair := range [][2]string{
+			{a.Principal, b.Principal},
+			{a.ResourceType, b.ResourceType},
+			{a.ResourceName, b.ResourceName},
+			{a.PatternType, b.PatternType},
+			{a.Host, b.Host},
+			{a.Operation, b.Operation},
+			{a.Permission, b.Permission},
+		} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return false
+	})
+}
+
+func waitForPropagationAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Validators: []validator.String{
+			stringvalidator.RegexMatches(durationRegex, "Value must be a duration, e.g. 30s, 2m or 0s"),
+		},
+	}
+}
+
+// aclPropagationTimeout is the wait_for_propagation duration, 30s when not set
+func aclPropagationTimeout(waitForPropagation types.String) (time.Duration, error) {
+	if waitForPropagation.IsNull() || waitForPropagation.IsUnknown() {
+		return defaultAclPropagationTimeout, nil
+	}
+	return time.ParseDuration(waitForPropagation.ValueString())
+}
+
+// waitForAclPropagation lists the ACLs of the principal until every rule is visible, so applications deployed right
+// after apply are authorized. A zero timeout doesn't wait.
+func waitForAclPropagation(ctx context.Context, c *client.Client, restEndpoint, clusterId, principal string, rules []AclRuleModel, waitForPropagation types.String) error {
+	timeout, err := aclPropagationTimeout(waitForPropagation)
+	if err != nil {
+		return err
+	}
+	if timeout == 0 || len(rules) == 0 {
+		return nil
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		aclsFound, err := listPrincipalAcls(c, restEndpoint, clusterId, principal)
+		if err != nil {
+			return err
+		}
+		listed := make(map[string]bool, len(aclsFound))
+		for _, acl := range aclsFound {
+			listed[aclRuleFromAcl(acl).key()] = true
+		}
+		var missing []string
+		for _, rule := range rules {
+			if !listed[rule.key()] {
+				missing = append(missing, rule.key())
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+		if time.Now().Add(aclPropagationPollInterval).After(deadline) {
+			return fmt.Errorf("ACLs of principal %s still not listed after %s: %s", principal, timeout, strings.Join(missing, ", "))
+		}
+		tflog.Debug(ctx, "Waiting for ACL propagation", map[string]interface{}{"principal": principal, "missing": len(missing)})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(aclPropagationPollInterval):
+		}
+	}
+}
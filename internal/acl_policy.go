@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource types restricted by name prefix rules without resource_types
+var defaultNamePrefixResourceTypes = []string{"TOPIC", "GROUP", "TRANSACTIONAL_ID"}
+
+type AclPolicyModel struct {
+	ForbidWildcardAllow     types.Bool               `tfsdk:"forbid_wildcard_allow"`
+	ForbidClusterAlter      types.Bool               `tfsdk:"forbid_cluster_alter"`
+	ProductionTopicPrefixes []string                 `tfsdk:"production_topic_prefixes"`
+	NamePrefixRules         []AclNamePrefixRuleModel `tfsdk:"name_prefix_rule"`
+}
+
+type AclNamePrefixRuleModel struct {
+	ServiceAccountPattern types.String `tfsdk:"service_account_pattern"`
+	AllowedPrefixes       []string     `tfsdk:"allowed_prefixes"`
+	ResourceTypes         []string     `tfsdk:"resource_types"`
+}
+
+func aclPolicyBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Attributes: map[string]schema.Attribute{
+			"forbid_wildcard_allow": schema.BoolAttribute{
+				Optional: true,
+			},
+			"forbid_cluster_alter": schema.BoolAttribute{
+				Optional: true,
+			},
+			"production_topic_prefixes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"name_prefix_rule": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"service_account_pattern": schema.StringAttribute{
+							Required: true,
+						},
+						"allowed_prefixes": schema.ListAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+						},
+						"resource_types": schema.ListAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(aclResourceTypes...)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// aclPolicy holds the guardrails of the provider acl_policy block, checked when planning every ACL-producing resource
+type aclPolicy struct {
+	forbidWildcardAllow     bool
+	forbidClusterAlter      bool
+	productionTopicPrefixes []string
+	namePrefixRules         []aclNamePrefixRule
+}
+
+type aclNamePrefixRule struct {
+	name                  string
+	serviceAccountPattern *regexp.Regexp
+	allowedPrefixes       []string
+	resourceTypes         []string
+}
+
+// newAclPolicy compiles the acl_policy block. A nil model is no policy.
+func newAclPolicy(model *AclPolicyModel) (*aclPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if model == nil {
+		return nil, diags
+	}
+	policyPath := path.Root("acl_policy")
+	policy := &aclPolicy{
+		forbidWildcardAllow:     model.ForbidWildcardAllow.ValueBool(),
+		forbidClusterAlter:      model.ForbidClusterAlter.ValueBool(),
+		productionTopicPrefixes: model.ProductionTopicPrefixes,
+	}
+	for i, ruleModel := range model.NamePrefixRules {
+		pattern, err := regexp.Compile(ruleModel.ServiceAccountPattern.ValueString())
+		if err != nil {
+			diags.AddAttributeError(policyPath.AtName("name_prefix_rule").AtListIndex(i).AtName("service_account_pattern"), "Invalid service account pattern", err.Error())
+			continue
+		}
+		resourceTypes := ruleModel.ResourceTypes
+		if len(resourceTypes) == 0 {
+			resourceTypes = defaultNamePrefixResourceTypes
+		}
+		policy.namePrefixRules = append(policy.namePrefixRules, aclNamePrefixRule{
+			name:                  fmt.Sprintf("name_prefix_rule[%d]", i),
+			serviceAccountPattern: pattern,
+			allowedPrefixes:       ruleModel.AllowedPrefixes,
+			resourceTypes:         resourceTypes,
+		})
+	}
+	return policy, diags
+}
+
+// violations returns the policy rules the ACL breaks. The owner is the service account name, or the principal for
+// ACLs configured by principal. Unknown values are skipped, they are checked again once known.
+func (p *aclPolicy) violations(owner, principal types.String, rule AclRuleModel) []aclProblem {
+	if p == nil {
+		return nil
+	}
+	known := func(values ...types.String) bool {
+		for _, value := range values {
+			if value.IsNull() || value.IsUnknown() {
+				return false
+			}
+		}
+		return true
+	}
+	var problems []aclProblem
+
+	if p.forbidWildcardAllow && known(principal, rule.Permission) &&
+		strings.HasSuffix(principal.ValueString(), ":*") && rule.Permission.ValueString() == "ALLOW" {
+		problems = append(problems, aclProblem{"permission", fmt.Sprintf(
+			"acl_policy.forbid_wildcard_allow: ALLOW ACLs for the wildcard principal %s are forbidden", principal.ValueString(),
+		)})
+	}
+	if p.forbidClusterAlter && known(rule.ResourceType, rule.Operation, rule.Permission) &&
+		rule.ResourceType.ValueString() == "CLUSTER" && rule.Permission.ValueString() == "ALLOW" &&
+		(rule.Operation.ValueString() == "ALTER" || rule.Operation.ValueString() == "ALL") {
+		problems = append(problems, aclProblem{"operation", fmt.Sprintf(
+			"acl_policy.forbid_cluster_alter: allowing %s on the cluster is forbidden", rule.Operation.ValueString(),
+		)})
+	}
+	if len(p.productionTopicPrefixes) > 0 && known(rule.ResourceType, rule.ResourceName, rule.PatternType) &&
+		rule.ResourceType.ValueString() == "TOPIC" {
+		resourceName, patternType := rule.ResourceName.ValueString(), rule.PatternType.ValueString()
+		switch {
+		case patternType == "LITERAL" && resourceName == client.AclWildcardResource:
+			problems = append(problems, aclProblem{"resource_name", "acl_policy.production_topic_prefixes: " +
+				"the wildcard topic * covers production topics, ACLs on production topics must be LITERAL"})
+		case patternType != "LITERAL" && p.prefixCoversProductionTopics(resourceName):
+			problems = append(problems, aclProblem{"pattern_type", fmt.Sprintf(
+				"acl_policy.production_topic_prefixes: %s ACLs on %s cover production topics, ACLs on production topics must be LITERAL",
+				patternType, resourceName,
+			)})
+		}
+	}
+	if !known(owner, rule.ResourceType, rule.ResourceName) {
+		return problems
+	}
+	for _, prefixRule := range p.namePrefixRules {
+		if !prefixRule.serviceAccountPattern.MatchString(owner.ValueString()) || !containsString(prefixRule.resourceTypes, rule.ResourceType.ValueString()) {
+			continue
+		}
+		allowed := false
+		for _, prefix := range prefixRule.allowedPrefixes {
+			allowed = allowed || strings.HasPrefix(rule.ResourceName.ValueString(), prefix)
+		}
+		if !allowed {
+			problems = append(problems, aclProblem{"resource_name", fmt.Sprintf(
+				"acl_policy.%s: %s names of %s must start with one of %s, got %s",
+				prefixRule.name, rule.ResourceType.ValueString(), owner.ValueString(),
+				strings.Join(prefixRule.allowedPrefixes, ", "), rule.ResourceName.ValueString(),
+			)})
+		}
+	}
+	return problems
+}
+
+// prefixCoversProductionTopics tells whether names starting with the prefix can be production topics: the prefix
+// starts with a production prefix, or a production prefix starts with it
+func (p *aclPolicy) prefixCoversProductionTopics(prefix string) bool {
+	for _, productionPrefix := range p.productionTopicPrefixes {
+		if strings.HasPrefix(prefix, productionPrefix) || strings.HasPrefix(productionPrefix, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// planAclOwner reads the service account name or principal of a planned or stored resource, as matched by name prefix
+// rules
+func planAclOwner(ctx context.Context, plan attributeGetter, diags *diag.Diagnostics) (owner, principal types.String) {
+	var serviceAccountName types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("service_account_name"), &serviceAccountName)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("principal"), &principal)...)
+	if serviceAccountName.IsNull() {
+		return principal, principal
+	}
+	return serviceAccountName, principal
+}
+
+// checkPlannedAclRules reports the policy violations of the ACLs planned in a set attribute, naming each ACL
+func (p *aclPolicy) checkPlannedAclRules(ctx context.Context, plan tfsdk.Plan, attribute string, diags *diag.Diagnostics) {
+	if p == nil {
+		return
+	}
+	owner, principal := planAclOwner(ctx, plan, diags)
+	var rulesSet types.Set
+	diags.Append(plan.GetAttribute(ctx, path.Root(attribute), &rulesSet)...)
+	if diags.HasError() || rulesSet.IsNull() || rulesSet.IsUnknown() {
+		return
+	}
+	var rules []AclRuleModel
+	diags.Append(rulesSet.ElementsAs(ctx, &rules, false)...)
+	if diags.HasError() {
+		return
+	}
+	for _, rule := range rules {
+		for _, problem := range p.violations(owner, principal, rule) {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"ACL policy violation",
+				fmt.Sprintf("%s %s %s %s: %s", rule.Permission.ValueString(), rule.Operation.ValueString(), rule.ResourceType.ValueString(), rule.ResourceName.ValueString(), problem.detail),
+			)
+		}
+	}
+}
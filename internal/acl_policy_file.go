@@ -0,0 +1,272 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// aclPolicyFileVersion is the only version of the policy file format so far
+const aclPolicyFileVersion = 1
+
+// yamlErrorRegex extracts the line of yaml syntax errors, e.g. "yaml: line 3: mapping values are not allowed in this context"
+var yamlErrorRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// aclPolicyFile is an access request of an application team, in YAML or JSON:
+//
+//	version: 1
+//	principals:
+//	  - name: orders-app
+//	    service_account: orders-app
+//	    roles: [producer, consumer]
+//	    topics: [orders.]
+//	    groups: [orders-app.]
+//
+// Topics, groups and transactional ids are prefixes, expanded into ACLs like confluentacl_kafka_client_access does
+type aclPolicyFile struct {
+	Version    int
+	Principals []aclPolicyFilePrincipal
+}
+
+type aclPolicyFilePrincipal struct {
+	Name             string
+	Principal        string
+	ServiceAccount   string
+	Roles            []string
+	Topics           []string
+	Groups           []string
+	TransactionalIds []string
+}
+
+// aclPolicyFileError locates a problem in the policy file, lines and columns start at 1. The column of syntax errors
+// is unknown.
+type aclPolicyFileError struct {
+	line    int
+	column  int
+	message string
+}
+
+func (e aclPolicyFileError) Error() string {
+	if e.column == 0 {
+		return fmt.Sprintf("line %d: %s", e.line, e.message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", e.line, e.column, e.message)
+}
+
+// aclPolicyFileParser collects every problem of the file, so they can be fixed at once
+type aclPolicyFileParser struct {
+	errors []aclPolicyFileError
+}
+
+func (p *aclPolicyFileParser) addError(node *yaml.Node, format string, args ...any) {
+	p.errors = append(p.errors, aclPolicyFileError{line: node.Line, column: node.Column, message: fmt.Sprintf(format, args...)})
+}
+
+// parseAclPolicyFile parses and validates a policy file. JSON being valid YAML, both formats are parsed the same way.
+func parseAclPolicyFile(content string) (*aclPolicyFile, []aclPolicyFileError) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		if match := yamlErrorRegex.FindStringSubmatch(err.Error()); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			return nil, []aclPolicyFileError{{line: line, message: match[2]}}
+		}
+		return nil, []aclPolicyFileError{{line: 1, message: strings.TrimPrefix(err.Error(), "yaml: ")}}
+	}
+	if len(document.Content) == 0 {
+		return nil, []aclPolicyFileError{{line: 1, column: 1, message: "policy file is empty"}}
+	}
+
+	p := &aclPolicyFileParser{}
+	file := &aclPolicyFile{}
+	root := document.Content[0]
+	fields := p.mapping(root, "version", "principals")
+	if root.Kind != yaml.MappingNode {
+		return nil, p.errors
+	}
+	if version, ok := fields["version"]; !ok {
+		p.addError(root, "version is required")
+	} else if err := version.Decode(&file.Version); err != nil || version.Kind != yaml.ScalarNode {
+		p.addError(version, "version must be a number")
+	} else if file.Version != aclPolicyFileVersion {
+		p.addError(version, "unsupported version %d, supported versions: %d", file.Version, aclPolicyFileVersion)
+	}
+	principals, ok := fields["principals"]
+	if !ok {
+		p.addError(root, "principals is required")
+	} else if principals.Kind != yaml.SequenceNode {
+		p.addError(principals, "principals must be a list")
+	} else {
+		names := make(map[string]bool, len(principals.Content))
+		for _, node := range principals.Content {
+			principal := p.principal(node)
+			if principal == nil {
+				continue
+			}
+			if names[principal.Name] {
+				p.addError(node, "duplicate principal name %q", principal.Name)
+			}
+			names[principal.Name] = true
+			file.Principals = append(file.Principals, *principal)
+		}
+	}
+	if len(p.errors) > 0 {
+		sort.SliceStable(p.errors, func(i, j int) bool {
+			a, b := p.errors[i], p.errors[j]
+			return a.line < b.line || (a.line == b.line && a.column < b.column)
+		})
+		return nil, p.errors
+	}
+	return file, nil
+}
+
+// mapping returns the values of a mapping by key, reporting unknown and duplicate keys
+func (p *aclPolicyFileParser) mapping(node *yaml.Node, keys ...string) map[string]*yaml.Node {
+	values := make(map[string]*yaml.Node)
+	if node.Kind != yaml.MappingNode {
+		p.addError(node, "expected an object with the keys %s", strings.Join(keys, ", "))
+		return values
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		switch {
+		case !slices.Contains(keys, key.Value):
+			p.addError(key, "unknown key %q, expected one of %s", key.Value, strings.Join(keys, ", "))
+		case values[key.Value] != nil:
+			p.addError(key, "duplicate key %q", key.Value)
+		default:
+			values[key.Value] = value
+		}
+	}
+	return values
+}
+
+func (p *aclPolicyFileParser) principal(node *yaml.Node) *aclPolicyFilePrincipal {
+	if node.Kind != yaml.MappingNode {
+		p.addError(node, "principals must be objects")
+		return nil
+	}
+	errorCount := len(p.errors)
+	fields := p.mapping(node, "name", "principal", "service_account", "roles", "topics", "groups", "transactional_ids")
+	principal := &aclPolicyFilePrincipal{
+		Name:             p.string(node, fields, "name", true),
+		Principal:        p.string(node, fields, "principal", false),
+		ServiceAccount:   p.string(node, fields, "service_account", false),
+		Roles:            p.strings(node, fields, "roles", true),
+		Topics:           p.strings(node, fields, "topics", true),
+		Groups:           p.strings(node, fields, "groups", false),
+		TransactionalIds: p.strings(node, fields, "transactional_ids", false),
+	}
+	if (fields["principal"] == nil) == (fields["service_account"] == nil) {
+		p.addError(node, "exactly one of principal or service_account is required")
+	}
+	if principal.Principal != "" && !principalRegex.MatchString(principal.Principal) {
+		p.addError(fields["principal"], "invalid principal %q. %s", principal.Principal, principalValidationMessage)
+	}
+	for _, roleNode := range principal.roleNodes(fields) {
+		switch role := roleNode.Value; role {
+		case kafkaClientRoleProducer:
+		case kafkaClientRoleConsumer, kafkaClientRoleStreamsApp:
+			if len(principal.Groups) == 0 {
+				p.addError(roleNode, "role %s requires groups", role)
+			}
+		case kafkaClientRoleTransactionalProducer:
+			if len(principal.TransactionalIds) == 0 {
+				p.addError(roleNode, "role %s requires transactional_ids", role)
+			}
+		default:
+			p.addError(roleNode, "unknown role %q, expected one of %s", role, strings.Join([]string{
+				kafkaClientRoleProducer, kafkaClientRoleConsumer, kafkaClientRoleTransactionalProducer, kafkaClientRoleStreamsApp,
+			}, ", "))
+		}
+	}
+	if len(p.errors) > errorCount {
+		return nil
+	}
+	return principal
+}
+
+// roleNodes returns the valid items of the roles list, to report problems of each role at its line
+func (principal *aclPolicyFilePrincipal) roleNodes(fields map[string]*yaml.Node) []*yaml.Node {
+	if principal.Roles == nil {
+		return nil
+	}
+	var nodes []*yaml.Node
+	for _, node := range fields["roles"].Content {
+		if slices.Contains(principal.Roles, node.Value) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// string returns a non-empty string value of the mapping
+func (p *aclPolicyFileParser) string(parent *yaml.Node, fields map[string]*yaml.Node, key string, required bool) string {
+	node, ok := fields[key]
+	if !ok {
+		if required {
+			p.addError(parent, "%s is required", key)
+		}
+		return ""
+	}
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!str" || node.Value == "" {
+		p.addError(node, "%s must be a non-empty string", key)
+		return ""
+	}
+	return node.Value
+}
+
+// strings returns a list of non-empty strings of the mapping, which must not be empty when required
+func (p *aclPolicyFileParser) strings(parent *yaml.Node, fields map[string]*yaml.Node, key string, required bool) []string {
+	node, ok := fields[key]
+	if !ok {
+		if required {
+			p.addError(parent, "%s is required", key)
+		}
+		return nil
+	}
+	if node.Kind != yaml.SequenceNode || (required && len(node.Content) == 0) {
+		p.addError(node, "%s must be a non-empty list of strings", key)
+		return nil
+	}
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode || item.Tag != "!!str" || item.Value == "" {
+			p.addError(item, "%s must only contain non-empty strings", key)
+			continue
+		}
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+// rules expands the roles of the principal into ACLs, for every combination of its prefixes
+func (principal aclPolicyFilePrincipal) rules() []AclRuleModel {
+	orEmpty := func(values []string) []string {
+		if len(values) == 0 {
+			return []string{""}
+		}
+		return values
+	}
+	var rules []AclRuleModel
+	seen := make(map[string]bool)
+	for _, role := range principal.Roles {
+		for _, topic := range principal.Topics {
+			for _, group := range orEmpty(principal.Groups) {
+				for _, transactionalId := range orEmpty(principal.TransactionalIds) {
+					for _, rule := range kafkaClientAccessRules(role, topic, group, transactionalId) {
+						if !seen[rule.key()] {
+							seen[rule.key()] = true
+							rules = append(rules, rule)
+						}
+					}
+				}
+			}
+		}
+	}
+	return rules
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseAclPolicyFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"valid", `
+version: 1
+principals:
+  - name: orders-app
+    service_account: orders-app
+    roles: [producer, consumer]
+    topics: [orders.]
+    groups: [orders-app.]
+  - name: all
+    principal: User:*
+    roles: [transactional_producer]
+    topics: [public.]
+    transactional_ids: [public-tx.]
+`, nil},
+		{"json", `{"version": 1, "principals": [{"name": "all", "principal": "User:*", "roles": ["producer"], "topics": ["public."]}]}`, nil},
+		{"empty", "", []string{"line 1, column 1: policy file is empty"}},
+		{"syntax error", "version: 1\nprincipals: [\n", []string{"line 2: did not find expected node content"}},
+		{"not an object", "- version: 1\n", []string{"line 1, column 1: expected an object with the keys version, principals"}},
+		{"duplicate key", "version: 1\nversion: 1\nprincipals: []\n", []string{`line 2, column 1: duplicate key "version"`}},
+		{"unknown key", "version: 1\nprincipals: []\nowner: team-a\n", []string{`line 3, column 1: unknown key "owner", expected one of version, principals`}},
+		{"missing version", "principals: []\n", []string{"line 1, column 1: version is required"}},
+		{"unsupported version", "version: 2\nprincipals: []\n", []string{"line 1, column 10: unsupported version 2, supported versions: 1"}},
+		{"version not a number", "version: one\nprincipals: []\n", []string{"line 1, column 10: version must be a number"}},
+		{"version not a scalar", "version: [1]\nprincipals: []\n", []string{"line 1, column 10: version must be a number"}},
+		{"principals not a list", "version: 1\nprincipals: orders-app\n", []string{"line 2, column 13: principals must be a list"}},
+		{"principal not an object", "version: 1\nprincipals: [orders-app]\n", []string{"line 2, column 14: principals must be objects"}},
+		{"duplicate principal name", `
+version: 1
+principals:
+  - name: a
+    principal: User:*
+    roles: [producer]
+    topics: [t.]
+  - name: a
+    principal: User:*
+    roles: [producer]
+    topics: [t.]
+`, []string{`line 8, column 5: duplicate principal name "a"`}},
+		{"neither principal nor service account", `
+version: 1
+principals:
+  - name: a
+    roles: [producer]
+    topics: [t.]
+`, []string{"line 4, column 5: exactly one of principal or service_account is required"}},
+		{"both principal and service account", `
+version: 1
+principals:
+  - name: a
+    principal: User:*
+    service_account: a
+    roles: [producer]
+    topics: [t.]
+`, []string{"line 4, column 5: exactly one of principal or service_account is required"}},
+		{"invalid principal", `
+version: 1
+principals:
+  - name: a
+    principal: sa-123abc
+    roles: [producer]
+    topics: [t.]
+`, []string{`line 5, column 16: invalid principal "sa-123abc"`}},
+		{"unknown role and missing groups", `
+version: 1
+principals:
+  - name: a
+    principal: User:*
+    roles: [writer, consumer]
+    topics: [t.]
+`, []string{
+			`line 6, column 13: unknown role "writer", expected one of producer, consumer, transactional_producer, streams_app`,
+			"line 6, column 21: role consumer requires groups",
+		}},
+		{"missing transactional ids", `
+version: 1
+principals:
+  - name: a
+    principal: User:*
+    roles:
+      - transactional_producer
+    topics: [t.]
+`, []string{"line 7, column 9: role transactional_producer requires transactional_ids"}},
+		{"invalid lists", `
+version: 1
+principals:
+  - name: ""
+    principal: User:*
+    roles: []
+    topics: [1, ""]
+    groups: g.
+`, []string{
+			"line 4, column 11: name must be a non-empty string",
+			"line 6, column 12: roles must be a non-empty list of strings",
+			"line 7, column 14: topics must only contain non-empty strings",
+			"line 7, column 17: topics must only contain non-empty strings",
+			"line 8, column 13: groups must be a non-empty list of strings",
+		}},
+		{"json errors", `{"version": 1, "principals": [{"name": "a", "principal": "User:*", "roles": ["producer"], "topics": ["t."], "colour": "red"}]}`,
+			[]string{`line 1, column 109: unknown key "colour"`}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, errs := parseAclPolicyFile(test.content)
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got errors %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], test.want[i]) {
+					t.Errorf("got error %q, want %q", got[i], test.want[i])
+				}
+			}
+			if (file == nil) != (len(test.want) > 0) {
+				t.Errorf("got file %v with errors %q", file, got)
+			}
+		})
+	}
+}
+
+func TestParseAclPolicyFilePrincipals(t *testing.T) {
+	file, errs := parseAclPolicyFile(`
+version: 1
+principals:
+  - name: orders-app
+    service_account: orders-app
+    roles: [streams_app]
+    topics: [orders., payments.]
+    groups: [orders-app]
+    transactional_ids: [orders-app]
+`)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	want := []aclPolicyFilePrincipal{{
+		Name:             "orders-app",
+		ServiceAccount:   "orders-app",
+		Roles:            []string{"streams_app"},
+		Topics:           []string{"orders.", "payments."},
+		Groups:           []string{"orders-app"},
+		TransactionalIds: []string{"orders-app"},
+	}}
+	if file.Version != 1 || !reflect.DeepEqual(file.Principals, want) {
+		t.Errorf("got version %d and principals %+v, want %+v", file.Version, file.Principals, want)
+	}
+}
+
+func TestAclPolicyFilePrincipalRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal aclPolicyFilePrincipal
+		want      []string
+	}{
+		{"every topic", aclPolicyFilePrincipal{Roles: []string{"producer"}, Topics: []string{"a.", "b."}}, []string{
+			"TOPIC#a.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#a.#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+			"TOPIC#b.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#b.#PREFIXED#*#DESCRIBE#ALLOW",
+		}},
+		{"roles sharing ACLs", aclPolicyFilePrincipal{Roles: []string{"producer", "consumer"}, Topics: []string{"a."}, Groups: []string{"g1", "g2"}}, []string{
+			"TOPIC#a.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#a.#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+			"TOPIC#a.#PREFIXED#*#READ#ALLOW",
+			"GROUP#g1#PREFIXED#*#READ#ALLOW",
+			"GROUP#g1#PREFIXED#*#DESCRIBE#ALLOW",
+			"GROUP#g2#PREFIXED#*#READ#ALLOW",
+			"GROUP#g2#PREFIXED#*#DESCRIBE#ALLOW",
+		}},
+		{"every transactional id", aclPolicyFilePrincipal{Roles: []string{"transactional_producer"}, Topics: []string{"a."}, TransactionalIds: []string{"tx1", "tx2"}}, []string{
+			"TOPIC#a.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#a.#PREFIXED#*#DESCRIBE#ALLOW",
+			"TRANSACTIONAL_ID#tx1#PREFIXED#*#WRITE#ALLOW",
+			"TRANSACTIONAL_ID#tx1#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+			"TRANSACTIONAL_ID#tx2#PREFIXED#*#WRITE#ALLOW",
+			"TRANSACTIONAL_ID#tx2#PREFIXED#*#DESCRIBE#ALLOW",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, rule := range test.principal.rules() {
+				got = append(got, rule.key())
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got ACLs %q, want %q", got, test.want)
+			}
+		})
+	}
+}
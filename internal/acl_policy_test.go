@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAclPolicyViolations(t *testing.T) {
+	policy, diags := newAclPolicy(&AclPolicyModel{
+		ForbidWildcardAllow:     types.BoolValue(true),
+		ForbidClusterAlter:      types.BoolValue(true),
+		ProductionTopicPrefixes: []string{"prod.", "live-"},
+		NamePrefixRules: []AclNamePrefixRuleModel{{
+			ServiceAccountPattern: types.StringValue("^team-a-"),
+			AllowedPrefixes:       []string{"team-a.", "shared."},
+		}},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	serviceAccount, principal := types.StringValue("team-a-app"), types.StringValue("User:sa-123abc")
+	tests := []struct {
+		name      string
+		owner     types.String
+		principal types.String
+		rule      AclRuleModel
+		want      []string
+	}{
+		{"allowed", serviceAccount, principal, newAclRule("TOPIC", "team-a.orders", "PREFIXED", "READ", "ALLOW"), nil},
+		{"wildcard principal allow", types.StringValue("User:*"), types.StringValue("User:*"),
+			newAclRule("GROUP", "shared", "LITERAL", "READ", "ALLOW"), []string{"permission: acl_policy.forbid_wildcard_allow"}},
+		{"wildcard principal deny", types.StringValue("User:*"), types.StringValue("User:*"),
+			newAclRule("GROUP", "shared", "LITERAL", "READ", "DENY"), nil},
+		{"cluster alter", principal, principal, newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "ALTER", "ALLOW"),
+			[]string{"operation: acl_policy.forbid_cluster_alter"}},
+		{"cluster all", principal, principal, newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "ALL", "ALLOW"),
+			[]string{"operation: acl_policy.forbid_cluster_alter"}},
+		{"cluster describe", principal, principal, newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "DESCRIBE", "ALLOW"), nil},
+		{"literal production topic", principal, principal, newAclRule("TOPIC", "prod.orders", "LITERAL", "READ", "ALLOW"), nil},
+		{"prefixed production topic", principal, principal, newAclRule("TOPIC", "prod.orders", "PREFIXED", "READ", "ALLOW"),
+			[]string{"pattern_type: acl_policy.production_topic_prefixes"}},
+		{"prefix of production topics", principal, principal, newAclRule("TOPIC", "prod", "PREFIXED", "READ", "ALLOW"),
+			[]string{"pattern_type: acl_policy.production_topic_prefixes"}},
+		{"empty prefix", principal, principal, newAclRule("TOPIC", "", "PREFIXED", "READ", "ALLOW"),
+			[]string{"pattern_type: acl_policy.production_topic_prefixes"}},
+		{"other prefix", principal, principal, newAclRule("TOPIC", "production", "PREFIXED", "READ", "ALLOW"), nil},
+		{"wildcard topic", principal, principal, newAclRule("TOPIC", "*", "LITERAL", "READ", "ALLOW"),
+			[]string{"resource_name: acl_policy.production_topic_prefixes"}},
+		{"prefixed group", principal, principal, newAclRule("GROUP", "prod", "PREFIXED", "READ", "ALLOW"), nil},
+		{"name outside allowed prefixes", serviceAccount, principal, newAclRule("TOPIC", "team-b.orders", "LITERAL", "READ", "ALLOW"),
+			[]string{"resource_name: acl_policy.name_prefix_rule[0]"}},
+		{"cluster outside name prefix rule", serviceAccount, principal, newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "IDEMPOTENT_WRITE", "ALLOW"), nil},
+		{"other service account", types.StringValue("team-b-app"), principal, newAclRule("TOPIC", "team-b.orders", "LITERAL", "READ", "ALLOW"), nil},
+		{"unknown owner", types.StringUnknown(), types.StringUnknown(), newAclRule("TOPIC", "team-b.orders", "LITERAL", "READ", "ALLOW"), nil},
+		{"unknown resource name", principal, principal, AclRuleModel{
+			ResourceType: types.StringValue("TOPIC"), ResourceName: types.StringUnknown(), PatternType: types.StringValue("PREFIXED"),
+			Host: types.StringValue("*"), Operation: types.StringValue("READ"), Permission: types.StringValue("ALLOW"),
+		}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []string
+			for _, problem := range policy.violations(test.owner, test.principal, test.rule) {
+				got = append(got, problem.attribute+": "+problem.detail)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got problems %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], test.want[i]) {
+					t.Errorf("got problem %q, want %q", got[i], test.want[i])
+				}
+			}
+		})
+	}
+
+	var noPolicy *aclPolicy
+	if problems := noPolicy.violations(principal, principal, newAclRule("TOPIC", "*", "LITERAL", "ALL", "ALLOW")); problems != nil {
+		t.Errorf("got problems %v without policy", problems)
+	}
+}
+
+func TestPrefixCoversProductionTopics(t *testing.T) {
+	policy := &aclPolicy{productionTopicPrefixes: []string{"prod.", "live-"}}
+	tests := []struct {
+		prefix string
+		want   bool
+	}{
+		{"", true},
+		{"pr", true},
+		{"prod.", true},
+		{"prod.orders", true},
+		{"li", true},
+		{"live-payments", true},
+		{"production", false},
+		{"lime", false},
+		{"dev.prod.", false},
+	}
+	for _, test := range tests {
+		if got := policy.prefixCoversProductionTopics(test.prefix); got != test.want {
+			t.Errorf("prefixCoversProductionTopics(%q) = %v, want %v", test.prefix, got, test.want)
+		}
+	}
+}
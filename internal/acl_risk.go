@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// aclGrantRisks returns why allowing the ACL is a high-risk grant. Rules with unknown values aren't assessed.
+func aclGrantRisks(rule AclRuleModel) []string {
+	for _, value := range []types.String{rule.ResourceType, rule.ResourceName, rule.PatternType, rule.Operation, rule.Permission} {
+		if value.IsNull() || value.IsUnknown() {
+			return nil
+		}
+	}
+	if rule.Permission.ValueString() != "ALLOW" {
+		return nil
+	}
+	var risks []string
+	resourceType, operation := rule.ResourceType.ValueString(), rule.Operation.ValueString()
+	if resourceType == "CLUSTER" && (operation == "ALTER" || operation == "ALTER_CONFIGS") {
+		risks = append(risks, fmt.Sprintf("%s on the cluster can change ACLs and broker configurations", operation))
+	}
+	if operation == "ALL" {
+		risks = append(risks, fmt.Sprintf("ALL allows every operation on the %s, including future ones", strings.ToLower(resourceType)))
+	}
+	if resourceType == "TOPIC" && rule.PatternType.ValueString() == "LITERAL" && rule.ResourceName.ValueString() == "*" {
+		risks = append(risks, "the wildcard topic name applies to every topic of the cluster")
+	}
+	return risks
+}
+
+// describeAclChange summarises an ACL and the principal it applies to, e.g. ALLOW ALL on TOPIC orders (PREFIXED) for
+// service account my-app
+func describeAclChange(owner string, rule AclRuleModel) string {
+	return client.AclSummary(client.ACLListResponse{
+		Principal:    owner,
+		ResourceType: rule.ResourceType.ValueString(),
+		ResourceName: rule.ResourceName.ValueString(),
+		PatternType:  rule.PatternType.ValueString(),
+		Operation:    rule.Operation.ValueString(),
+		Permission:   rule.Permission.ValueString(),
+	})
+}
+
+// aclOwner is the principal a planned or stored resource applies its ACLs to
+type aclOwner struct {
+	// principal is empty while it's unknown
+	principal string
+	// description names the service account or principal in warnings
+	description string
+}
+
+// key identifies the owner when comparing the state and the plan. The principal is compared when known, so naming the
+// same principal by service account isn't a change.
+func (o aclOwner) key() string {
+	if o.principal != "" {
+		return o.principal
+	}
+	return o.description
+}
+
+// readAclOwner reads the owner of a planned or stored resource
+func readAclOwner(ctx context.Context, data attributeGetter, diags *diag.Diagnostics) aclOwner {
+	owner, principal := planAclOwner(ctx, data, diags)
+	result := aclOwner{principal: principal.ValueString(), description: "principal " + principal.ValueString()}
+	if principal.IsUnknown() {
+		result.principal = ""
+	}
+	switch {
+	case owner.IsUnknown():
+		result.description = "a principal known after apply"
+	case owner != principal:
+		result.description = "service account " + owner.ValueString()
+	}
+	return result
+}
+
+// warnHighRiskAclChanges adds a warning for each high-risk ACL the plan grants and each DENY ACL it removes, so the
+// risk shows up in plan output. ACLs both planned and stored for the same principal are unchanged and not reported;
+// when the principal changes, every planned ACL is a new grant and every stored ACL a removal.
+func warnHighRiskAclChanges(attributePath path.Path, plannedOwner aclOwner, planned []AclRuleModel, previousOwner aclOwner, previous []AclRuleModel, diags *diag.Diagnostics) {
+	previousKeys := make(map[string]bool, len(previous))
+	for _, rule := range previous {
+		previousKeys[previousOwner.key()+"/"+rule.key()] = true
+	}
+	plannedKeys := make(map[string]bool, len(planned))
+	for _, rule := range planned {
+		key := plannedOwner.key() + "/" + rule.key()
+		plannedKeys[key] = true
+		if previousKeys[key] {
+			continue
+		}
+		for _, risk := range aclGrantRisks(rule) {
+			diags.AddAttributeWarning(attributePath, "High-risk ACL grant",
+				fmt.Sprintf("Grants %s: %s", describeAclChange(plannedOwner.description, rule), risk))
+		}
+	}
+	for _, rule := range previous {
+		if plannedKeys[previousOwner.key()+"/"+rule.key()] || rule.Permission.ValueString() != "DENY" {
+			continue
+		}
+		diags.AddAttributeWarning(attributePath, "DENY ACL removal",
+			fmt.Sprintf("Removes %s: access it denied may become allowed by other ACLs", describeAclChange(previousOwner.description, rule)))
+	}
+}
+
+// warnHighRiskAclRuleChanges compares the ACLs of a set attribute between the state and the plan, which is null when
+// the resource is destroyed
+func warnHighRiskAclRuleChanges(ctx context.Context, req resource.ModifyPlanRequest, plan attributeGetter, attribute string, diags *diag.Diagnostics) {
+	readRules := func(data attributeGetter) ([]AclRuleModel, bool) {
+		var rulesSet types.Set
+		diags.Append(data.GetAttribute(ctx, path.Root(attribute), &rulesSet)...)
+		if rulesSet.IsUnknown() {
+			return nil, false
+		}
+		var rules []AclRuleModel
+		diags.Append(rulesSet.ElementsAs(ctx, &rules, false)...)
+		return rules, true
+	}
+	var planned, previous []AclRuleModel
+	var plannedOwner, previousOwner aclOwner
+	if !req.State.Raw.IsNull() {
+		previous, _ = readRules(req.State)
+		previousOwner = readAclOwner(ctx, req.State, diags)
+	}
+	if !req.Plan.Raw.IsNull() {
+		var known bool
+		if planned, known = readRules(plan); !known {
+			return
+		}
+		plannedOwner = readAclOwner(ctx, plan, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+	warnHighRiskAclChanges(path.Root(attribute), plannedOwner, planned, previousOwner, previous, diags)
+}
+
+// warnHighRiskRootAclChange compares the ACL at the root of the confluentacl_acl resource between the state and the
+// plan, which is null when the resource is destroyed
+func warnHighRiskRootAclChange(ctx context.Context, req resource.ModifyPlanRequest, diags *diag.Diagnostics) {
+	var planned, previous []AclRuleModel
+	var plannedOwner, previousOwner aclOwner
+	if !req.State.Raw.IsNull() {
+		previous = append(previous, rootAclRule(ctx, req.State, diags))
+		previousOwner = readAclOwner(ctx, req.State, diags)
+	}
+	if !req.Plan.Raw.IsNull() {
+		planned = append(planned, rootAclRule(ctx, req.Plan, diags))
+		plannedOwner = readAclOwner(ctx, req.Plan, diags)
+	}
+	if diags.HasError() {
+		return
+	}
+	warnHighRiskAclChanges(path.Empty(), plannedOwner, planned, previousOwner, previous, diags)
+}
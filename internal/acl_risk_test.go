@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAclGrantRisks(t *testing.T) {
+	tests := []struct {
+		name string
+		rule AclRuleModel
+		want int
+	}{
+		{"read topic", newAclRule("TOPIC", "orders", "LITERAL", "READ", "ALLOW"), 0},
+		{"cluster alter", newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "ALTER", "ALLOW"), 1},
+		{"cluster alter configs", newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "ALTER_CONFIGS", "ALLOW"), 1},
+		{"topic alter", newAclRule("TOPIC", "orders", "LITERAL", "ALTER", "ALLOW"), 0},
+		{"all", newAclRule("GROUP", "orders", "PREFIXED", "ALL", "ALLOW"), 1},
+		{"wildcard topic", newAclRule("TOPIC", "*", "LITERAL", "READ", "ALLOW"), 1},
+		{"all on wildcard topic", newAclRule("TOPIC", "*", "LITERAL", "ALL", "ALLOW"), 2},
+		{"prefixed wildcard", newAclRule("TOPIC", "*", "PREFIXED", "READ", "ALLOW"), 0},
+		{"deny all", newAclRule("TOPIC", "*", "LITERAL", "ALL", "DENY"), 0},
+		{"unknown operation", AclRuleModel{
+			ResourceType: types.StringValue("CLUSTER"), ResourceName: types.StringValue("kafka-cluster"), PatternType: types.StringValue("LITERAL"),
+			Host: types.StringValue("*"), Operation: types.StringUnknown(), Permission: types.StringValue("ALLOW"),
+		}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := aclGrantRisks(test.rule); len(got) != test.want {
+				t.Errorf("got risks %q, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWarnHighRiskAclChanges(t *testing.T) {
+	app := aclOwner{principal: "User:sa-1", description: "service account app"}
+	other := aclOwner{principal: "User:sa-2", description: "service account other"}
+	alter := newAclRule("CLUSTER", "kafka-cluster", "LITERAL", "ALTER", "ALLOW")
+	deny := newAclRule("TOPIC", "orders", "LITERAL", "READ", "DENY")
+	read := newAclRule("TOPIC", "orders", "LITERAL", "READ", "ALLOW")
+	tests := []struct {
+		name          string
+		plannedOwner  aclOwner
+		planned       []AclRuleModel
+		previousOwner aclOwner
+		previous      []AclRuleModel
+		want          []string
+	}{
+		{"create", app, []AclRuleModel{alter, read}, aclOwner{}, nil, []string{
+			"High-risk ACL grant: Grants ALLOW ALTER on CLUSTER kafka-cluster (LITERAL) for service account app",
+		}},
+		{"unchanged", app, []AclRuleModel{alter, deny}, app, []AclRuleModel{alter, deny}, nil},
+		{"same principal by another name", app, []AclRuleModel{alter, deny},
+			aclOwner{principal: "User:sa-1", description: "principal User:sa-1"}, []AclRuleModel{alter, deny}, nil},
+		{"destroy", aclOwner{}, nil, app, []AclRuleModel{alter, deny}, []string{
+			"DENY ACL removal: Removes DENY READ on TOPIC orders (LITERAL) for service account app",
+		}},
+		{"rule change", app, []AclRuleModel{read}, app, []AclRuleModel{deny}, []string{
+			"DENY ACL removal: Removes DENY READ on TOPIC orders (LITERAL) for service account app",
+		}},
+		{"owner change", other, []AclRuleModel{alter, deny}, app, []AclRuleModel{alter, deny}, []string{
+			"High-risk ACL grant: Grants ALLOW ALTER on CLUSTER kafka-cluster (LITERAL) for service account other",
+			"DENY ACL removal: Removes DENY READ on TOPIC orders (LITERAL) for service account app",
+		}},
+		{"owner known after apply", aclOwner{description: "service account other"}, []AclRuleModel{alter}, app, []AclRuleModel{alter}, []string{
+			"High-risk ACL grant: Grants ALLOW ALTER on CLUSTER kafka-cluster (LITERAL) for service account other",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			warnHighRiskAclChanges(path.Empty(), test.plannedOwner, test.planned, test.previousOwner, test.previous, &diags)
+			var got []string
+			for _, warning := range diags.Warnings() {
+				got = append(got, warning.Summary()+": "+warning.Detail())
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got warnings %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], test.want[i]) {
+					t.Errorf("got warning %q, want %q", got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
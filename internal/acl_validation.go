@@ -0,0 +1,165 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const clusterResourceName = "kafka-cluster"
+
+// aclOperationsByResourceType is the matrix of operations kafka accepts for each resource type. ALL is valid for every
+// resource type.
+var aclOperationsByResourceType = map[string][]string{
+	"TOPIC":            {"ALL", "READ", "WRITE", "CREATE", "DELETE", "ALTER", "DESCRIBE", "DESCRIBE_CONFIGS", "ALTER_CONFIGS"},
+	"GROUP":            {"ALL", "READ", "DELETE", "DESCRIBE"},
+	"CLUSTER":          {"ALL", "CREATE", "ALTER", "DESCRIBE", "CLUSTER_ACTION", "DESCRIBE_CONFIGS", "ALTER_CONFIGS", "IDEMPOTENT_WRITE"},
+	"TRANSACTIONAL_ID": {"ALL", "WRITE", "DESCRIBE"},
+	"DELEGATION_TOKEN": {"ALL", "DESCRIBE"},
+}
+
+var (
+	_ resource.ConfigValidator = aclValidator{}
+	_ resource.ConfigValidator = aclRulesValidator{}
+)
+
+// aclValidator checks the combination of the ACL attributes at the root of the confluentacl_acl resource
+type aclValidator struct{}
+
+func (v aclValidator) Description(_ context.Context) string {
+	return "Checks the operation, pattern type, resource name and host are valid for the resource type of the ACL"
+}
+
+func (v aclValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v aclValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	rule := rootAclRule(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, problem := range validateAclRule(rule) {
+		resp.Diagnostics.AddAttributeError(path.Root(problem.attribute), "Invalid ACL", problem.detail)
+	}
+}
+
+// aclRulesValidator checks every ACL of a set of ACL rules, like the rule blocks of confluentacl_acl_set
+type aclRulesValidator struct {
+	attribute string
+}
+
+func (v aclRulesValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Checks the operation, pattern type, resource name and host are valid for the resource type of each %s", v.attribute)
+}
+
+func (v aclRulesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v aclRulesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rulesSet types.Set
+	diags := req.Config.GetAttribute(ctx, path.Root(v.attribute), &rulesSet)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || rulesSet.IsNull() || rulesSet.IsUnknown() {
+		return
+	}
+	var rules []AclRuleModel
+	diags = rulesSet.ElementsAs(ctx, &rules, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, rule := range rules {
+		for _, problem := range validateAclRule(rule) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(v.attribute),
+				"Invalid ACL",
+				fmt.Sprintf("%s %s %s: %s", rule.Operation.ValueString(), rule.ResourceType.ValueString(), rule.ResourceName.ValueString(), problem.detail),
+			)
+		}
+	}
+}
+
+// attributeGetter is a config, plan or state
+type attributeGetter interface {
+	GetAttribute(ctx context.Context, path path.Path, target interface{}) diag.Diagnostics
+}
+
+// rootAclRule reads the ACL attributes at the root of the confluentacl_acl resource, which may be unknown
+func rootAclRule(ctx context.Context, data attributeGetter, diags *diag.Diagnostics) AclRuleModel {
+	var rule AclRuleModel
+	for attribute, value := range map[string]*types.String{
+		"resource_type": &rule.ResourceType,
+		"resource_name": &rule.ResourceName,
+		"pattern_type":  &rule.PatternType,
+		"host":          &rule.Host,
+		"operation":     &rule.Operation,
+		"permission":    &rule.Permission,
+	} {
+		diags.Append(data.GetAttribute(ctx, path.Root(attribute), value)...)
+	}
+	return rule
+}
+
+type aclProblem struct {
+	attribute string
+	detail    string
+}
+
+// validateAclRule returns the reasons kafka would refuse to create the ACL. Unknown values are skipped, they are
+// checked again once known.
+func validateAclRule(rule AclRuleModel) []aclProblem {
+	var problems []aclProblem
+	known := func(value types.String) bool {
+		return !value.IsNull() && !value.IsUnknown()
+	}
+
+	if known(rule.PatternType) && rule.PatternType.ValueString() == "MATCH" {
+		problems = append(problems, aclProblem{"pattern_type", "MATCH is only a filter when listing ACLs, ACLs are created with LITERAL or PREFIXED patterns"})
+	}
+	if known(rule.Host) && rule.Host.ValueString() != "*" {
+		problems = append(problems, aclProblem{"host", fmt.Sprintf("Confluent Cloud only supports the host *, got %s", rule.Host.ValueString())})
+	}
+	if !known(rule.ResourceType) {
+		return problems
+	}
+
+	resourceType := rule.ResourceType.ValueString()
+	operations, ok := aclOperationsByResourceType[resourceType]
+	if !ok {
+		// Unknown resource types are reported by the schema validators
+		return problems
+	}
+	if known(rule.Operation) && !containsString(operations, rule.Operation.ValueString()) {
+		problems = append(problems, aclProblem{"operation", fmt.Sprintf(
+			"Operation %s isn't valid for %s resources, valid operations are %s",
+			rule.Operation.ValueString(), resourceType, strings.Join(operations, ", "),
+		)})
+	}
+	if resourceType == "CLUSTER" {
+		if known(rule.ResourceName) && rule.ResourceName.ValueString() != clusterResourceName {
+			problems = append(problems, aclProblem{"resource_name", fmt.Sprintf("The name of CLUSTER resources must be %s, got %s", clusterResourceName, rule.ResourceName.ValueString())})
+		}
+		if known(rule.PatternType) && rule.PatternType.ValueString() == "PREFIXED" {
+			problems = append(problems, aclProblem{"pattern_type", "CLUSTER resources only support the LITERAL pattern"})
+		}
+	}
+	return problems
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
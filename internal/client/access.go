@@ -0,0 +1,88 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AclWildcardPrincipal is the principal of ACLs applying to every user
+const AclWildcardPrincipal = "User:*"
+
+// impliedOperations lists the operations whose ALLOW ACLs also allow the key operation, as in kafka's authorizer
+var impliedOperations = map[string][]string{
+	"DESCRIBE":         {"READ", "WRITE", "DELETE", "ALTER"},
+	"DESCRIBE_CONFIGS": {"ALTER_CONFIGS"},
+}
+
+// AccessRequest is an operation a principal performs on a concrete resource
+type AccessRequest struct {
+	// Principals are every form of the principal, e.g. from PrincipalAliases
+	Principals   []string
+	Host         string
+	Operation    string
+	ResourceType string
+	ResourceName string
+}
+
+// AccessDecision is the outcome of evaluating ACLs for an access request, with the ACLs that decided it
+type AccessDecision struct {
+	Allowed      bool
+	Reason       string
+	DecidingAcls []ACLListResponse
+}
+
+// appliesTo tells whether the ACL applies to the principal, host and resource of the request, whatever its operation
+func (q *AccessRequest) appliesTo(acl ACLListResponse) bool {
+	if acl.ResourceType != q.ResourceType {
+		return false
+	}
+	if acl.Principal != AclWildcardPrincipal && !containsValue(q.Principals, acl.Principal) {
+		return false
+	}
+	if acl.Host != "*" && acl.Host != q.Host {
+		return false
+	}
+	switch acl.PatternType {
+	case AclPatternTypeLiteral:
+		return acl.ResourceName == q.ResourceName || acl.ResourceName == AclWildcardResource
+	case AclPatternTypePrefixed:
+		return strings.HasPrefix(q.ResourceName, acl.ResourceName)
+	}
+	return false
+}
+
+// EvaluateAccess decides whether the ACLs allow the request, like kafka's authorizer: a matching DENY ACL for the
+// operation or ALL denies, otherwise a matching ALLOW ACL for the operation, ALL or an operation implying it allows
+// (READ, WRITE, DELETE and ALTER imply DESCRIBE, ALTER_CONFIGS implies DESCRIBE_CONFIGS). Without any, access is denied.
+func EvaluateAccess(acls []ACLListResponse, q *AccessRequest) AccessDecision {
+	var denying, allowing []ACLListResponse
+	for _, acl := range acls {
+		if !q.appliesTo(acl) {
+			continue
+		}
+		switch {
+		case acl.Permission == "DENY" && (acl.Operation == q.Operation || acl.Operation == AclOperationAll):
+			denying = append(denying, acl)
+		case acl.Permission == "ALLOW" && (acl.Operation == q.Operation || acl.Operation == AclOperationAll ||
+			containsValue(impliedOperations[q.Operation], acl.Operation)):
+			allowing = append(allowing, acl)
+		}
+	}
+	resource := fmt.Sprintf("%s on %s %s", q.Operation, q.ResourceType, q.ResourceName)
+	if len(denying) > 0 {
+		return AccessDecision{Reason: fmt.Sprintf("%s is denied by %d DENY ACLs, which take precedence over ALLOW ACLs", resource, len(denying)), DecidingAcls: denying}
+	}
+	if len(allowing) > 0 {
+		return AccessDecision{Allowed: true, Reason: fmt.Sprintf("%s is allowed by %d ALLOW ACLs", resource, len(allowing)), DecidingAcls: allowing}
+	}
+	return AccessDecision{Reason: fmt.Sprintf("%s is denied, no ALLOW ACL matches", resource), DecidingAcls: []ACLListResponse{}}
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
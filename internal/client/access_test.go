@@ -0,0 +1,125 @@
+package client
+
+import "testing"
+
+func TestEvaluateAccess(t *testing.T) {
+	const principal = "User:sa-app"
+	tests := []struct {
+		name         string
+		acls         []ACLListResponse
+		operation    string
+		resourceName string
+		allowed      bool
+		deciding     int
+	}{
+		{
+			name:      "no ACL",
+			operation: "READ",
+		},
+		{
+			name:      "literal allow",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW")},
+			operation: "READ",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name:         "literal allow of another topic",
+			acls:         []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW")},
+			operation:    "READ",
+			resourceName: "orders-dlq",
+		},
+		{
+			name:      "prefixed allow",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "ord", "PREFIXED", "READ", "ALLOW")},
+			operation: "READ",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name:      "wildcard resource and principal",
+			acls:      []ACLListResponse{testAcl(AclWildcardPrincipal, "TOPIC", "*", "LITERAL", "WRITE", "ALLOW")},
+			operation: "WRITE",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name:      "other principal",
+			acls:      []ACLListResponse{testAcl("User:sa-other", "TOPIC", "orders", "LITERAL", "READ", "ALLOW")},
+			operation: "READ",
+		},
+		{
+			name:      "read implies describe",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW")},
+			operation: "DESCRIBE",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name:      "alter configs implies describe configs",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "ALTER_CONFIGS", "ALLOW")},
+			operation: "DESCRIBE_CONFIGS",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name:      "describe doesn't imply read",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "DESCRIBE", "ALLOW")},
+			operation: "READ",
+		},
+		{
+			name:      "all allows every operation",
+			acls:      []ACLListResponse{testAcl(principal, "TOPIC", "orders", "LITERAL", "ALL", "ALLOW")},
+			operation: "DELETE",
+			allowed:   true,
+			deciding:  1,
+		},
+		{
+			name: "deny takes precedence",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "*", "LITERAL", "ALL", "ALLOW"),
+				testAcl(principal, "TOPIC", "ord", "PREFIXED", "READ", "DENY"),
+			},
+			operation: "READ",
+			deciding:  1,
+		},
+		{
+			name: "deny all denies every operation",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "WRITE", "ALLOW"),
+				testAcl(AclWildcardPrincipal, "TOPIC", "orders", "LITERAL", "ALL", "DENY"),
+			},
+			operation: "WRITE",
+			deciding:  1,
+		},
+		{
+			name: "deny read doesn't deny describe",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "DESCRIBE", "ALLOW"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "DENY"),
+			},
+			operation: "DESCRIBE",
+			allowed:   true,
+			deciding:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resourceName := test.resourceName
+			if resourceName == "" {
+				resourceName = "orders"
+			}
+			decision := EvaluateAccess(test.acls, &AccessRequest{
+				Principals:   []string{principal},
+				Host:         "*",
+				Operation:    test.operation,
+				ResourceType: "TOPIC",
+				ResourceName: resourceName,
+			})
+			if decision.Allowed != test.allowed || len(decision.DecidingAcls) != test.deciding {
+				t.Errorf("expected allowed=%t with %d deciding ACLs, got allowed=%t with %v: %s",
+					test.allowed, test.deciding, decision.Allowed, decision.DecidingAcls, decision.Reason)
+			}
+		})
+	}
+}
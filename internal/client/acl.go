@@ -1,15 +1,33 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"terraform-provider-confluentacl/internal/client/request"
 )
 
 const (
-	kafkaAclEndpoint = "kafka/v3/clusters/%s/acls"
-	AclPatternTypeLiteral
+	kafkaClusterEndpoint = "kafka/v3/clusters/%s"
+	kafkaAclEndpoint     = "kafka/v3/clusters/%s/acls"
+
+	// AclAny matches every value of the resource type, pattern type, operation or permission of a filter
+	AclAny = "ANY"
+	// AclPatternTypeMatch filters the literal, wildcard and prefixed ACLs applying to the resource name of the filter
+	AclPatternTypeMatch    = "MATCH"
+	AclPatternTypeLiteral  = "LITERAL"
+	AclPatternTypePrefixed = "PREFIXED"
+	// AclOperationAll grants or denies every operation on the resource
+	AclOperationAll = "ALL"
+	// AclWildcardResource is the resource name of literal ACLs applying to every resource of their type
+	AclWildcardResource = "*"
 )
 
+// ErrKafkaClusterNotFound is returned when the rest endpoint doesn't serve the cluster
+var ErrKafkaClusterNotFound = errors.New("kafka cluster not found")
+
 type ACLRequest struct {
 	ResourceType string `json:"resource_type"`
 	ResourceName string `json:"resource_name"`
@@ -37,6 +55,70 @@ type ACLListResponse struct {
 	Permission   string `json:"permission"`
 }
 
+// Matches tells whether the ACL is selected by the request used as a filter, with the semantics of kafka ACL
+// filters: empty fields and ANY match every value, MATCH selects the ACLs applying to the resource name, and ALL is an
+// operation of its own rather than a wildcard.
+func (q *ACLRequest) Matches(acl ACLListResponse) bool {
+	matchesValue := func(filter, value string) bool {
+		return filter == "" || filter == AclAny || filter == value
+	}
+	if !matchesValue(q.ResourceType, acl.ResourceType) ||
+		!matchesValue(q.Operation, acl.Operation) ||
+		!matchesValue(q.Permission, acl.Permission) ||
+		(q.Principal != "" && q.Principal != acl.Principal) ||
+		(q.Host != "" && q.Host != acl.Host) {
+		return false
+	}
+	if q.PatternType != AclPatternTypeMatch {
+		return matchesValue(q.PatternType, acl.PatternType) && (q.ResourceName == "" || q.ResourceName == acl.ResourceName)
+	}
+	if q.ResourceName == "" {
+		return true
+	}
+	switch acl.PatternType {
+	case AclPatternTypeLiteral:
+		return acl.ResourceName == q.ResourceName || acl.ResourceName == AclWildcardResource
+	case AclPatternTypePrefixed:
+		return strings.HasPrefix(q.ResourceName, acl.ResourceName)
+	}
+	return false
+}
+
+// CheckKafkaCluster verifies the cluster is reachable at the rest endpoint. Successful checks are cached.
+func (c *Client) CheckKafkaCluster(restEndpoint, clusterId string) error {
+	cacheKey := "kafkaCluster:" + restEndpoint + "/" + clusterId
+	c.cacheMutex.RLock()
+	_, ok := c.cache[cacheKey]
+	c.cacheMutex.RUnlock()
+	if ok {
+		return nil
+	}
+
+	requestBuilder, err := c.KafkaRestRequestBuilder(restEndpoint)
+	if err != nil {
+		return err
+	}
+	response, err := requestBuilder.
+		Endpoint(fmt.Sprintf(kafkaClusterEndpoint, clusterId)).
+		Get().
+		ExecuteAndRetryOn429()
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s at %s", ErrKafkaClusterNotFound, clusterId, restEndpoint)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("reading cluster %s at %s failed. response status: %s", clusterId, restEndpoint, response.Status)
+	}
+
+	c.cacheMutex.Lock()
+	c.cache[cacheKey] = true
+	c.cacheMutex.Unlock()
+	return nil
+}
+
 func (c *Client) ListACLs(restEndpoint, clusterId string) ([]ACLListResponse, error) {
 	return c.ListSpecificACLs(restEndpoint, clusterId, nil)
 }
@@ -48,11 +130,10 @@ func (c *Client) ListSpecificACLs(restEndpoint, clusterId string, query *ACLRequ
 	if err != nil {
 		return nil, err
 	}
-	var queryParams map[string]string
-	if query == nil {
-		queryParams = make(map[string]string, 0)
-	} else {
-		queryParams = map[string]string{
+	queryParams := make(map[string]string, 0)
+	if query != nil {
+		// Empty fields aren't filters
+		for param, value := range map[string]string{
 			"principal":     query.Principal,
 			"resource_name": query.ResourceName,
 			"resource_type": query.ResourceType,
@@ -60,6 +141,10 @@ func (c *Client) ListSpecificACLs(restEndpoint, clusterId string, query *ACLRequ
 			"host":          query.Host,
 			"operation":     query.Operation,
 			"permission":    query.Permission,
+		} {
+			if value != "" {
+				queryParams[param] = value
+			}
 		}
 	}
 	response, err := requestBuilder.
@@ -74,16 +159,30 @@ func (c *Client) ListSpecificACLs(restEndpoint, clusterId string, query *ACLRequ
 	if err != nil {
 		return nil, err
 	}
-	return allAclsInCluster.Data, nil
+	if query == nil {
+		return allAclsInCluster.Data, nil
+	}
+	// The filter is applied again so ANY, MATCH and ALL have the same meaning whatever the REST proxy supports
+	aclsFound := make([]ACLListResponse, 0, len(allAclsInCluster.Data))
+	for _, acl := range allAclsInCluster.Data {
+		if query.Matches(acl) {
+			aclsFound = append(aclsFound, acl)
+		}
+	}
+	return aclsFound, nil
 }
 
-func (c *Client) CreateACL(restEndpoint, clusterId string, request *ACLRequest) error {
+func (c *Client) CreateACL(ctx context.Context, restEndpoint, clusterId string, request *ACLRequest) (err error) {
+	var response *http.Response
+	defer func() {
+		c.recordAudit(ctx, &AuditEntry{Action: "create_acl", Principal: request.Principal, ClusterId: clusterId, Acl: request}, response, err)
+	}()
 	endpoint := fmt.Sprintf(kafkaAclEndpoint, clusterId)
 	requestBuilder, err := c.KafkaRestRequestBuilder(restEndpoint)
 	if err != nil {
 		return err
 	}
-	response, err := requestBuilder.
+	response, err = requestBuilder.
 		Endpoint(endpoint).
 		SetBody(request).
 		Post().
@@ -97,7 +196,27 @@ func (c *Client) CreateACL(restEndpoint, clusterId string, request *ACLRequest)
 	return nil
 }
 
-func (c *Client) DeleteAcl(restEndpoint, clusterId string, query *ACLRequest) error {
+// IsExact tells whether the request identifies a single ACL, without empty fields, ANY or MATCH
+func (q *ACLRequest) IsExact() bool {
+	for _, value := range []string{q.Principal, q.ResourceType, q.ResourceName, q.PatternType, q.Host, q.Operation, q.Permission} {
+		if value == "" || value == AclAny {
+			return false
+		}
+	}
+	return q.PatternType != AclPatternTypeMatch
+}
+
+// DeleteAcl deletes the single ACL of the request. The kafka endpoint deletes every ACL matching its filters, so the
+// request must be exact, and the ACLs reported as deleted are checked to be that one ACL. An ACL already gone isn't
+// an error.
+func (c *Client) DeleteAcl(ctx context.Context, restEndpoint, clusterId string, query *ACLRequest) (err error) {
+	var response *http.Response
+	defer func() {
+		c.recordAudit(ctx, &AuditEntry{Action: "delete_acl", Principal: query.Principal, ClusterId: clusterId, Acl: query}, response, err)
+	}()
+	if !query.IsExact() {
+		return fmt.Errorf("refusing to delete ACLs matching %s, could delete more than one ACL", aclRequestDescription(query))
+	}
 	endpoint := fmt.Sprintf(kafkaAclEndpoint, clusterId)
 	requestBuilder, err := c.KafkaRestRequestBuilder(restEndpoint)
 	if err != nil {
@@ -112,7 +231,7 @@ func (c *Client) DeleteAcl(restEndpoint, clusterId string, query *ACLRequest) er
 		"operation":     query.Operation,
 		"permission":    query.Permission,
 	}
-	response, err := requestBuilder.
+	response, err = requestBuilder.
 		Endpoint(endpoint).
 		SetQueryParams(queryParams).
 		Delete().
@@ -120,8 +239,38 @@ func (c *Client) DeleteAcl(restEndpoint, clusterId string, query *ACLRequest) er
 	if err != nil {
 		return err
 	}
-	if response.StatusCode != 201 {
-		return fmt.Errorf("Create ACL Failure. Response " + response.Status)
+	if response.StatusCode != 200 {
+		return fmt.Errorf("delete ACL failure. response status: %s", response.Status)
+	}
+	var deleted ACLListResponseWrapper
+	err = request.UnpackJSONResponse(response, &deleted)
+	if err != nil {
+		return err
+	}
+	for _, acl := range deleted.Data {
+		if !query.Matches(acl) {
+			return fmt.Errorf("deleting ACL %s also deleted ACL %s", aclRequestDescription(query), aclDescription(acl))
+		}
+	}
+	if len(deleted.Data) > 1 {
+		return fmt.Errorf("deleting ACL %s deleted %d ACLs", aclRequestDescription(query), len(deleted.Data))
 	}
 	return nil
 }
+
+func aclRequestDescription(acl *ACLRequest) string {
+	return fmt.Sprintf("(principal=%q resource_type=%q resource_name=%q pattern_type=%q host=%q operation=%q permission=%q)",
+		acl.Principal, acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Host, acl.Operation, acl.Permission)
+}
+
+func aclDescription(acl ACLListResponse) string {
+	return aclRequestDescription(&ACLRequest{
+		Principal:    acl.Principal,
+		ResourceType: acl.ResourceType,
+		ResourceName: acl.ResourceName,
+		PatternType:  acl.PatternType,
+		Host:         acl.Host,
+		Operation:    acl.Operation,
+		Permission:   acl.Permission,
+	})
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// AclFindingRedundant is an ACL granting or denying nothing more than another ACL on the same resource pattern,
+	// e.g. the same ACL under a legacy numeric principal, or DESCRIBE next to READ
+	AclFindingRedundant = "redundant"
+	// AclFindingShadowed is an ACL covered by another ACL with a broader resource pattern, e.g. a LITERAL ACL on orders
+	// covered by a PREFIXED ACL on ord
+	AclFindingShadowed = "shadowed"
+	// AclFindingConflicting is an ALLOW ACL that never takes effect, as a DENY ACL covers it
+	AclFindingConflicting = "conflicting"
+)
+
+// AclFinding is an ACL that can be deleted without changing access, and the ACL it's covered by
+type AclFinding struct {
+	Kind      string
+	Principal string
+	Acl       ACLListResponse
+	CoveredBy ACLListResponse
+	Detail    string
+}
+
+// AnalyzeAcls reports the redundant, shadowed and conflicting ACLs of every principal. Principals are grouped by
+// their canonical form, given by aliases (e.g. User:12345 to User:sa-123abc), and ACLs of the wildcard principal
+// User:* apply to every principal. Of two ACLs covering each other, the one under an alias is reported, or else the
+// second one in the given order.
+func AnalyzeAcls(acls []ACLListResponse, aliases map[string]string) []AclFinding {
+	canonical := func(principal string) string {
+		if alias, ok := aliases[principal]; ok {
+			return alias
+		}
+		return principal
+	}
+	findings := make([]AclFinding, 0)
+	for i, acl := range acls {
+		principal := canonical(acl.Principal)
+		reported := make(map[string]bool)
+		for j, other := range acls {
+			if i == j {
+				continue
+			}
+			otherPrincipal := canonical(other.Principal)
+			if otherPrincipal != principal && otherPrincipal != AclWildcardPrincipal {
+				continue
+			}
+			if !aclScopeCovers(other, acl) {
+				continue
+			}
+			kind := ""
+			switch {
+			case acl.Permission == "ALLOW" && other.Permission == "DENY" && aclOperationCovers(other, acl, false):
+				kind = AclFindingConflicting
+			case acl.Permission != other.Permission || !aclOperationCovers(other, acl, acl.Permission == "ALLOW"):
+				continue
+			case !aclScopeCovers(acl, other):
+				kind = AclFindingShadowed
+			default:
+				if otherPrincipal == principal && aclOperationCovers(acl, other, acl.Permission == "ALLOW") {
+					// Both ACLs cover each other. The one under a legacy alias is reported, or else the second one.
+					aliased, otherAliased := acl.Principal != principal, other.Principal != principal
+					if (aliased == otherAliased && j > i) || (!aliased && otherAliased) {
+						continue
+					}
+				}
+				kind = AclFindingRedundant
+			}
+			if reported[kind] {
+				continue
+			}
+			reported[kind] = true
+			findings = append(findings, AclFinding{
+				Kind:      kind,
+				Principal: principal,
+				Acl:       acl,
+				CoveredBy: other,
+				Detail:    aclFindingDetail(kind, acl, other),
+			})
+		}
+	}
+	return findings
+}
+
+// aclScopeCovers tells whether every host and resource the ACL b applies to is also covered by a. Principals are
+// compared by the caller.
+func aclScopeCovers(a, b ACLListResponse) bool {
+	if a.ResourceType != b.ResourceType || (a.Host != "*" && a.Host != b.Host) {
+		return false
+	}
+	switch a.PatternType {
+	case AclPatternTypeLiteral:
+		return a.ResourceName == AclWildcardResource ||
+			(b.PatternType == AclPatternTypeLiteral && a.ResourceName == b.ResourceName)
+	case AclPatternTypePrefixed:
+		return b.ResourceName != AclWildcardResource && strings.HasPrefix(b.ResourceName, a.ResourceName)
+	}
+	return false
+}
+
+// aclOperationCovers tells whether the operation of a includes the operation of b, with implied operations when
+// allowing
+func aclOperationCovers(a, b ACLListResponse, allowing bool) bool {
+	if a.Operation == AclOperationAll || a.Operation == b.Operation {
+		return true
+	}
+	return allowing && containsValue(impliedOperations[b.Operation], a.Operation)
+}
+
+func aclFindingDetail(kind string, acl, coveredBy ACLListResponse) string {
+	switch kind {
+	case AclFindingConflicting:
+		return fmt.Sprintf("%s never takes effect, %s denies it", AclSummary(acl), AclSummary(coveredBy))
+	case AclFindingShadowed:
+		return fmt.Sprintf("%s is covered by the broader %s", AclSummary(acl), AclSummary(coveredBy))
+	}
+	return fmt.Sprintf("%s grants nothing more than %s", AclSummary(acl), AclSummary(coveredBy))
+}
+
+// AclSummary describes an ACL in findings and diagnostics, e.g. ALLOW READ on TOPIC orders (LITERAL) for
+// User:sa-123abc
+func AclSummary(acl ACLListResponse) string {
+	return fmt.Sprintf("%s %s on %s %s (%s) for %s", acl.Permission, acl.Operation, acl.ResourceType, acl.ResourceName, acl.PatternType, acl.Principal)
+}
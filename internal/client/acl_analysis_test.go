@@ -0,0 +1,107 @@
+package client
+
+import "testing"
+
+func TestAnalyzeAcls(t *testing.T) {
+	const principal = "User:sa-app"
+	tests := []struct {
+		name     string
+		acls     []ACLListResponse
+		aliases  map[string]string
+		expected []string
+	}{
+		{
+			name: "independent ACLs",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+				testAcl(principal, "TOPIC", "payments", "LITERAL", "READ", "ALLOW"),
+				testAcl(principal, "GROUP", "orders", "LITERAL", "READ", "ALLOW"),
+				testAcl("User:sa-other", "TOPIC", "orders", "PREFIXED", "READ", "ALLOW"),
+			},
+		},
+		{
+			name: "literal shadowed by prefixed",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "ord", "PREFIXED", "READ", "ALLOW"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+			},
+			expected: []string{"shadowed TOPIC orders READ"},
+		},
+		{
+			name: "shadowed by wildcard principal and resource",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+				testAcl(AclWildcardPrincipal, "TOPIC", "*", "LITERAL", "ALL", "ALLOW"),
+			},
+			expected: []string{"shadowed TOPIC orders READ"},
+		},
+		{
+			name: "implied operation is redundant",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "DESCRIBE", "ALLOW"),
+			},
+			expected: []string{"redundant TOPIC orders DESCRIBE"},
+		},
+		{
+			name: "denying describe isn't implied by denying read",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "DENY"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "DESCRIBE", "DENY"),
+			},
+		},
+		{
+			name: "same ACL under a legacy numeric principal",
+			acls: []ACLListResponse{
+				testAcl("User:12345", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+			},
+			aliases:  map[string]string{"User:12345": principal},
+			expected: []string{"redundant TOPIC orders READ User:12345"},
+		},
+		{
+			name: "second duplicate is redundant",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "DENY"),
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "READ", "DENY"),
+			},
+			expected: []string{"redundant TOPIC orders READ"},
+		},
+		{
+			name: "allow denied by a broader deny",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "orders", "LITERAL", "WRITE", "ALLOW"),
+				testAcl(principal, "TOPIC", "ord", "PREFIXED", "ALL", "DENY"),
+			},
+			expected: []string{"conflicting TOPIC orders WRITE"},
+		},
+		{
+			name: "narrower deny carving out an allow isn't a conflict",
+			acls: []ACLListResponse{
+				testAcl(principal, "TOPIC", "ord", "PREFIXED", "WRITE", "ALLOW"),
+				testAcl(principal, "TOPIC", "orders-audit", "LITERAL", "WRITE", "DENY"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			findings := AnalyzeAcls(test.acls, test.aliases)
+			var got []string
+			for _, finding := range findings {
+				description := finding.Kind + " " + finding.Acl.ResourceType + " " + finding.Acl.ResourceName + " " + finding.Acl.Operation
+				if finding.Acl.Principal != finding.Principal {
+					description += " " + finding.Acl.Principal
+				}
+				got = append(got, description)
+			}
+			if len(got) != len(test.expected) {
+				t.Fatalf("expected findings %v, got %v", test.expected, got)
+			}
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Errorf("expected findings %v, got %v", test.expected, got)
+				}
+			}
+		})
+	}
+}
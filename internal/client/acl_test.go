@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testClusterId = "lkc-test"
+
+func testAcl(principal, resourceType, resourceName, patternType, operation, permission string) ACLListResponse {
+	return aclListResponse(testClusterId, &ACLRequest{
+		Principal:    principal,
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		PatternType:  patternType,
+		Host:         "*",
+		Operation:    operation,
+		Permission:   permission,
+	})
+}
+
+func TestListSpecificACLsFilters(t *testing.T) {
+	server, c := newFakeServer(t)
+	literal := testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "READ", "ALLOW")
+	wildcard := testAcl("User:sa-1", "TOPIC", "*", "LITERAL", "ALL", "DENY")
+	prefixed := testAcl("User:sa-2", "TOPIC", "ord", "PREFIXED", "WRITE", "ALLOW")
+	otherTopic := testAcl("User:sa-2", "TOPIC", "payments", "LITERAL", "READ", "ALLOW")
+	group := testAcl("User:sa-1", "GROUP", "orders", "LITERAL", "READ", "ALLOW")
+	server.addAcls(testClusterId, literal, wildcard, prefixed, otherTopic, group)
+
+	tests := []struct {
+		name  string
+		query *ACLRequest
+		want  []ACLListResponse
+	}{
+		{"no filter", nil, []ACLListResponse{literal, wildcard, prefixed, otherTopic, group}},
+		{"empty filter", &ACLRequest{}, []ACLListResponse{literal, wildcard, prefixed, otherTopic, group}},
+		{"principal", &ACLRequest{Principal: "User:sa-2"}, []ACLListResponse{prefixed, otherTopic}},
+		{"any", &ACLRequest{ResourceType: AclAny, PatternType: AclAny, Operation: AclAny, Permission: AclAny}, []ACLListResponse{literal, wildcard, prefixed, otherTopic, group}},
+		{"all is an operation", &ACLRequest{Operation: AclOperationAll}, []ACLListResponse{wildcard}},
+		{"literal", &ACLRequest{ResourceType: "TOPIC", ResourceName: "orders", PatternType: AclPatternTypeLiteral}, []ACLListResponse{literal}},
+		{"match", &ACLRequest{ResourceType: "TOPIC", ResourceName: "orders", PatternType: AclPatternTypeMatch}, []ACLListResponse{literal, wildcard, prefixed}},
+		{"match any resource type", &ACLRequest{ResourceType: AclAny, ResourceName: "orders", PatternType: AclPatternTypeMatch}, []ACLListResponse{literal, wildcard, prefixed, group}},
+		{"exact", &ACLRequest{Principal: "User:sa-1", ResourceType: "TOPIC", ResourceName: "orders", PatternType: AclPatternTypeLiteral, Host: "*", Operation: "READ", Permission: "ALLOW"}, []ACLListResponse{literal}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.ListSpecificACLs(server.URL, testClusterId, test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got %d ACLs %v, want %d %v", len(got), got, len(test.want), test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Errorf("ACL %d: got %v, want %v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCreateACLWithAllOperation(t *testing.T) {
+	server, c := newFakeServer(t)
+	request := &ACLRequest{
+		Principal:    "User:sa-1",
+		ResourceType: "GROUP",
+		ResourceName: "orders",
+		PatternType:  AclPatternTypePrefixed,
+		Host:         "*",
+		Operation:    AclOperationAll,
+		Permission:   "ALLOW",
+	}
+	if err := c.CreateACL(context.Background(), server.URL, testClusterId, request); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.ListSpecificACLs(server.URL, testClusterId, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != aclListResponse(testClusterId, request) {
+		t.Errorf("got %v, want the created ACL", got)
+	}
+	got, err = c.ListSpecificACLs(server.URL, testClusterId, &ACLRequest{Principal: "User:sa-1", Operation: "READ"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("an ALL ACL was listed as a READ ACL: %v", got)
+	}
+}
+
+func aclRequest(acl ACLListResponse) *ACLRequest {
+	return &ACLRequest{
+		Principal:    acl.Principal,
+		ResourceType: acl.ResourceType,
+		ResourceName: acl.ResourceName,
+		PatternType:  acl.PatternType,
+		Host:         acl.Host,
+		Operation:    acl.Operation,
+		Permission:   acl.Permission,
+	}
+}
+
+func TestDeleteAcl(t *testing.T) {
+	server, c := newFakeServer(t)
+	deleted := testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "READ", "ALLOW")
+	kept := testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "WRITE", "ALLOW")
+	server.addAcls(testClusterId, deleted, kept)
+
+	if err := c.DeleteAcl(context.Background(), server.URL, testClusterId, aclRequest(deleted)); err != nil {
+		t.Fatal(err)
+	}
+	if acls := server.clusterAcls(testClusterId); len(acls) != 1 || acls[0] != kept {
+		t.Errorf("got ACLs %v after delete, want %v", acls, []ACLListResponse{kept})
+	}
+
+	// Already gone
+	if err := c.DeleteAcl(context.Background(), server.URL, testClusterId, aclRequest(deleted)); err != nil {
+		t.Errorf("deleting a missing ACL failed: %s", err)
+	}
+}
+
+func TestDeleteAclChecksDeletedAcls(t *testing.T) {
+	acl := testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "READ", "ALLOW")
+	tests := []struct {
+		name    string
+		extra   ACLListResponse
+		wantErr string
+	}{
+		{"non-matching ACL", testAcl("User:sa-2", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"), "also deleted ACL"},
+		{"more than one ACL", acl, "deleted 2 ACLs"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, c := newFakeServer(t)
+			server.addAcls(testClusterId, acl)
+			server.reportExtraDeletedAcls(test.extra)
+
+			err := c.DeleteAcl(context.Background(), server.URL, testClusterId, aclRequest(acl))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("got error %v, want %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteAclRefusesFilters(t *testing.T) {
+	server, c := newFakeServer(t)
+	acls := []ACLListResponse{
+		testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:sa-1", "TOPIC", "orders", "LITERAL", "WRITE", "ALLOW"),
+	}
+	server.addAcls(testClusterId, acls...)
+
+	for name, change := range map[string]func(*ACLRequest){
+		"any operation":   func(q *ACLRequest) { q.Operation = AclAny },
+		"match pattern":   func(q *ACLRequest) { q.PatternType = AclPatternTypeMatch },
+		"empty principal": func(q *ACLRequest) { q.Principal = "" },
+		"empty host":      func(q *ACLRequest) { q.Host = "" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			query := aclRequest(acls[0])
+			change(query)
+			if err := c.DeleteAcl(context.Background(), server.URL, testClusterId, query); err == nil {
+				t.Error("expected an error")
+			}
+			if got := server.clusterAcls(testClusterId); len(got) != len(acls) {
+				t.Errorf("got %d ACLs, want %d", len(got), len(acls))
+			}
+		})
+	}
+}
+
+func TestCheckKafkaCluster(t *testing.T) {
+	server, c := newFakeServer(t)
+	server.addAcls(testClusterId)
+
+	if err := c.CheckKafkaCluster(server.URL, testClusterId); err != nil {
+		t.Errorf("existing cluster: %s", err)
+	}
+	if err := c.CheckKafkaCluster(server.URL, "lkc-missing"); !errors.Is(err, ErrKafkaClusterNotFound) {
+		t.Errorf("expected ErrKafkaClusterNotFound for a missing cluster, got %v", err)
+	}
+}
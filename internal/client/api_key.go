@@ -1,7 +1,10 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 	"terraform-provider-confluentacl/internal/client/request"
 )
 
@@ -40,7 +43,8 @@ type ApiKeyIamV2Spec struct {
 	Owner       ApiKeyIamV2Owner    `json:"owner"`
 }
 type ApiKeyIamV2Resource struct {
-	ID string `json:"id"`
+	ID          string `json:"id"`
+	Environment string `json:"environment"`
 }
 type ApiKeyIamV2Owner struct {
 	ID string `json:"id"`
@@ -85,7 +89,20 @@ const (
 	deleteApiKeyEndpoint = "api_keys/%s"        // internal api
 )
 
-func (c *Client) CreateApiKey(userId int, envId, resourceId, description string) (*ApiKeyInternal, error) {
+func (c *Client) CreateApiKey(ctx context.Context, userId int, envId, resourceId, description string) (apiKey *ApiKeyInternal, err error) {
+	var response *http.Response
+	defer func() {
+		entry := &AuditEntry{Action: "create_api_key", Principal: fmt.Sprintf("User:%d", userId), ClusterId: resourceId, EnvironmentId: envId}
+		var secret string
+		if apiKey != nil {
+			entry.ApiKey = apiKey.Key
+			entry.ApiKeyId = strconv.Itoa(apiKey.ID)
+			secret = apiKey.Secret
+			// Api key resources are identified by the id of the key, unknown until it's created
+			ctx = withCreatedAuditResourceId(ctx, entry.ApiKeyId)
+		}
+		c.recordAudit(ctx, entry, response, err, secret)
+	}()
 	body := &ApiKeyCreateRequestW{
 		&ApiKeyCreateRequest{
 			AccountID:       envId,
@@ -95,7 +112,7 @@ func (c *Client) CreateApiKey(userId int, envId, resourceId, description string)
 		},
 	}
 	responseBody := &ApiKeyResponseInternal{}
-	response, err := c.RequestBuilder().Endpoint(createApiKeyEndpoint).SetBody(&body).Post().ExecuteAndRetryOn429()
+	response, err = c.RequestBuilder().Endpoint(createApiKeyEndpoint).SetBody(&body).Post().ExecuteAndRetryOn429()
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +145,11 @@ func (c *Client) ReadApiKey(apiKey string) (*ApiKeyIamV2, error) {
 	return responseBody, nil
 }
 
-func (c *Client) UpdateApiKey(id, description, envId, resourceId string) error {
+func (c *Client) UpdateApiKey(ctx context.Context, id, description, envId, resourceId string) (err error) {
+	var response *http.Response
+	defer func() {
+		c.recordAudit(ctx, &AuditEntry{Action: "update_api_key", ApiKeyId: id, ClusterId: resourceId, EnvironmentId: envId}, response, err)
+	}()
 	body := &ApiKeyUpdateRequestW{
 		&ApiKeyUpdateRequest{
 			ID:              id,
@@ -137,7 +158,7 @@ func (c *Client) UpdateApiKey(id, description, envId, resourceId string) error {
 			Description:     description,
 		},
 	}
-	response, err := c.RequestBuilder().Endpoint(fmt.Sprintf(updateApiKeyEndpoint, id)).SetBody(&body).Put().ExecuteAndRetryOn429()
+	response, err = c.RequestBuilder().Endpoint(fmt.Sprintf(updateApiKeyEndpoint, id)).SetBody(&body).Put().ExecuteAndRetryOn429()
 	if err != nil {
 		return err
 	}
@@ -147,7 +168,11 @@ func (c *Client) UpdateApiKey(id, description, envId, resourceId string) error {
 	return nil
 }
 
-func (c *Client) DeleteApiKey(id, envId, resourceId string) error {
+func (c *Client) DeleteApiKey(ctx context.Context, id, envId, resourceId string) (err error) {
+	var response *http.Response
+	defer func() {
+		c.recordAudit(ctx, &AuditEntry{Action: "delete_api_key", ApiKeyId: id, ClusterId: resourceId, EnvironmentId: envId}, response, err)
+	}()
 	body := &ApiKeyDeleteRequestW{
 		&ApiKeyDeleteRequest{
 			ID:              id,
@@ -155,7 +180,7 @@ func (c *Client) DeleteApiKey(id, envId, resourceId string) error {
 			LogicalClusters: []LogicalCluster{{ID: resourceId}},
 		},
 	}
-	response, err := c.RequestBuilder().Endpoint(fmt.Sprintf(deleteApiKeyEndpoint, id)).SetBody(&body).Delete().ExecuteAndRetryOn429()
+	response, err = c.RequestBuilder().Endpoint(fmt.Sprintf(deleteApiKeyEndpoint, id)).SetBody(&body).Delete().ExecuteAndRetryOn429()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	auditOutcomeSuccess = "success"
+	auditOutcomeFailure = "failure"
+	auditRedacted       = "[REDACTED]"
+	requestIdHeader     = "X-Request-Id"
+)
+
+// AuditLog appends one JSON line per mutating client call to a local file.
+type AuditLog struct {
+	path  string
+	mutex sync.Mutex
+}
+
+type AuditEntry struct {
+	Timestamp     string      `json:"timestamp"`
+	Action        string      `json:"action"`
+	ResourceType  string      `json:"resource_type,omitempty"`
+	ResourceId    string      `json:"resource_id,omitempty"`
+	Principal     string      `json:"principal,omitempty"`
+	ClusterId     string      `json:"cluster_id,omitempty"`
+	EnvironmentId string      `json:"environment_id,omitempty"`
+	Acl           *ACLRequest `json:"acl,omitempty"`
+	ApiKey        string      `json:"api_key,omitempty"`
+	ApiKeyId      string      `json:"api_key_id,omitempty"`
+	Outcome       string      `json:"outcome"`
+	HttpStatus    int         `json:"http_status,omitempty"`
+	RequestId     string      `json:"request_id,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+type auditSourceKey struct{}
+
+type auditSource struct {
+	resourceType string
+	resourceId   string
+}
+
+// WithAuditSource attaches the terraform resource responsible for the following client calls to the context.
+// Terraform doesn't send resource addresses to providers, so the resource type and id are recorded instead.
+func WithAuditSource(ctx context.Context, resourceType, resourceId string) context.Context {
+	return context.WithValue(ctx, auditSourceKey{}, auditSource{resourceType: resourceType, resourceId: resourceId})
+}
+
+// withCreatedAuditResourceId sets the resource id of an audit source created without one, for resources identified by
+// what the call creates
+func withCreatedAuditResourceId(ctx context.Context, resourceId string) context.Context {
+	source, ok := ctx.Value(auditSourceKey{}).(auditSource)
+	if !ok || source.resourceId != "" {
+		return ctx
+	}
+	source.resourceId = resourceId
+	return context.WithValue(ctx, auditSourceKey{}, source)
+}
+
+// EnableAuditLog makes every mutating call of this client append an entry to the file in auditLogPath.
+func (c *Client) EnableAuditLog(auditLogPath string) error {
+	file, err := os.OpenFile(auditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	c.auditLog = &AuditLog{path: auditLogPath}
+	return file.Close()
+}
+
+func (l *AuditLog) Record(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// recordAudit completes the entry with the call outcome and writes it to the audit log, if enabled.
+// Failing to write the audit log doesn't fail the call, as the change was already made in Confluent.
+func (c *Client) recordAudit(ctx context.Context, entry *AuditEntry, response *http.Response, callErr error, secrets ...string) {
+	if c.auditLog == nil {
+		return
+	}
+	entry.Timestamp = time.Now().UTC().Format(time.RFC3339Nano)
+	if source, ok := ctx.Value(auditSourceKey{}).(auditSource); ok {
+		entry.ResourceType = source.resourceType
+		entry.ResourceId = source.resourceId
+	}
+	entry.Outcome = auditOutcomeSuccess
+	if response != nil {
+		entry.HttpStatus = response.StatusCode
+		entry.RequestId = response.Header.Get(requestIdHeader)
+	}
+	if callErr != nil {
+		entry.Outcome = auditOutcomeFailure
+		entry.Error = c.redact(callErr.Error(), secrets...)
+	}
+	if err := c.auditLog.Record(entry); err != nil {
+		tflog.Error(ctx, "Failed to write audit log entry", map[string]interface{}{
+			"path":   c.auditLog.path,
+			"action": entry.Action,
+			"error":  err.Error(),
+		})
+	}
+}
+
+func (c *Client) redact(text string, secrets ...string) string {
+	for _, secret := range append(secrets, c.cloudApiSecret) {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, auditRedacted)
+		}
+	}
+	return text
+}
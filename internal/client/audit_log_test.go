@@ -0,0 +1,150 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func readAuditLog(t *testing.T, auditLogPath string) (string, []AuditEntry) {
+	content, err := os.ReadFile(auditLogPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("audit log line %q isn't JSON: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return string(content), entries
+}
+
+func TestAuditLog(t *testing.T) {
+	server, c := newFakeServer(t)
+	server.addAcls(testClusterId)
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+	if err := c.EnableAuditLog(auditLogPath); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithAuditSource(context.Background(), "confluentacl_acl", "acl-id")
+	acl := &ACLRequest{Principal: "User:sa-1", ResourceType: "TOPIC", ResourceName: "orders", PatternType: "LITERAL", Host: "*", Operation: "READ", Permission: "ALLOW"}
+	if _, err := c.ListACLs(server.URL, testClusterId); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateACL(ctx, server.URL, testClusterId, acl); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteAcl(ctx, server.URL, testClusterId, acl); err != nil {
+		t.Fatal(err)
+	}
+	apiKey, err := c.CreateApiKey(ctx, 123, "env-1", testClusterId, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if apiKey.Secret != testApiKeySecret {
+		t.Fatalf("got api key secret %q, want %q", apiKey.Secret, testApiKeySecret)
+	}
+	server.failMutations(http.StatusForbidden)
+	if err := c.CreateACL(ctx, server.URL, testClusterId, acl); err == nil {
+		t.Fatal("creating the ACL didn't fail")
+	}
+
+	content, entries := readAuditLog(t, auditLogPath)
+	want := []struct {
+		action     string
+		outcome    string
+		httpStatus int
+	}{
+		{"create_acl", auditOutcomeSuccess, http.StatusCreated},
+		{"delete_acl", auditOutcomeSuccess, http.StatusOK},
+		{"create_api_key", auditOutcomeSuccess, http.StatusOK},
+		{"create_acl", auditOutcomeFailure, http.StatusForbidden},
+	}
+	// Listing ACLs doesn't change anything, so it isn't audited
+	if len(entries) != len(want) {
+		t.Fatalf("got %d audit log entries, want %d:\n%s", len(entries), len(want), content)
+	}
+	for i, entry := range entries {
+		if entry.Action != want[i].action || entry.Outcome != want[i].outcome || entry.HttpStatus != want[i].httpStatus {
+			t.Errorf("entry %d: got %s %s %d, want %s %s %d", i, entry.Action, entry.Outcome, entry.HttpStatus,
+				want[i].action, want[i].outcome, want[i].httpStatus)
+		}
+		if !strings.HasPrefix(entry.RequestId, "request-") {
+			t.Errorf("entry %d: got request id %q", i, entry.RequestId)
+		}
+		if entry.ResourceType != "confluentacl_acl" || entry.ResourceId != "acl-id" {
+			t.Errorf("entry %d: got resource %s %s", i, entry.ResourceType, entry.ResourceId)
+		}
+		if entry.Timestamp == "" {
+			t.Errorf("entry %d: no timestamp", i)
+		}
+	}
+	if entries[2].ApiKey != "TESTKEY" || entries[2].Principal != "User:123" {
+		t.Errorf("got api key %q of %q", entries[2].ApiKey, entries[2].Principal)
+	}
+	if entries[3].Error == "" {
+		t.Error("failed call has no error")
+	}
+	for _, secret := range []string{testCloudApiSecret, testApiKeySecret} {
+		if strings.Contains(content, secret) {
+			t.Errorf("audit log contains secret %q:\n%s", secret, content)
+		}
+	}
+}
+
+func TestAuditLogCreatedApiKeyId(t *testing.T) {
+	_, c := newFakeServer(t)
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+	if err := c.EnableAuditLog(auditLogPath); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := WithAuditSource(context.Background(), "confluentacl_api_key", "")
+	apiKey, err := c.CreateApiKey(ctx, 123, "env-1", testClusterId, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, entries := readAuditLog(t, auditLogPath)
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit log entries, want 1:\n%s", len(entries), content)
+	}
+	if want := strconv.Itoa(apiKey.ID); entries[0].ResourceType != "confluentacl_api_key" || entries[0].ResourceId != want {
+		t.Errorf("got resource %s %q, want confluentacl_api_key %q", entries[0].ResourceType, entries[0].ResourceId, want)
+	}
+}
+
+func TestAuditLogRedactsErrors(t *testing.T) {
+	_, c := newFakeServer(t)
+	auditLogPath := filepath.Join(t.TempDir(), "audit.log")
+	if err := c.EnableAuditLog(auditLogPath); err != nil {
+		t.Fatal(err)
+	}
+
+	callErr := errors.New("request with " + testCloudApiSecret + " failed, created key secret " + testApiKeySecret)
+	c.recordAudit(context.Background(), &AuditEntry{Action: "create_api_key"}, nil, callErr, testApiKeySecret)
+
+	content, entries := readAuditLog(t, auditLogPath)
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit log entries, want 1:\n%s", len(entries), content)
+	}
+	want := "request with " + auditRedacted + " failed, created key secret " + auditRedacted
+	if entries[0].Error != want {
+		t.Errorf("got error %q, want %q", entries[0].Error, want)
+	}
+	if entries[0].Outcome != auditOutcomeFailure || entries[0].HttpStatus != 0 || entries[0].RequestId != "" {
+		t.Errorf("got outcome %s, http status %d, request id %q without response", entries[0].Outcome, entries[0].HttpStatus, entries[0].RequestId)
+	}
+}
@@ -18,6 +18,8 @@ type Client struct {
 	accessToken    CachedAccessToken
 	cache          map[string]interface{}
 	cacheMutex     sync.RWMutex
+	auditLog       *AuditLog
+	apiUrl         string
 }
 
 const baseApiUrl = "https://confluent.cloud/api/"
@@ -29,11 +31,17 @@ func New(cloudApiKey, cloudApiSecret string) *Client {
 		accessToken:    CachedAccessToken{},
 		cache:          make(map[string]interface{}),
 		cacheMutex:     sync.RWMutex{},
+		apiUrl:         baseApiUrl,
 	}
 }
 
+// SetApiUrl points the client to another confluent cloud api than https://confluent.cloud/api/, e.g. a fake one
+func (c *Client) SetApiUrl(apiUrl string) {
+	c.apiUrl = apiUrl
+}
+
 func (c *Client) RequestBuilder() *request.Request {
-	return request.NewRequestWithBasicAuth(baseApiUrl, c.cloudApiKey, c.cloudApiSecret)
+	return request.NewRequestWithBasicAuth(c.apiUrl, c.cloudApiKey, c.cloudApiSecret)
 }
 
 func (c *Client) KafkaRestRequestBuilder(kafkaHttpEndpoint string) (*request.Request, error) {
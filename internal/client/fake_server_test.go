@@ -0,0 +1,209 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer serves the confluent cloud and kafka REST endpoints used by the client from memory. ACL listing ignores
+// the query filters like an older REST proxy would, so filtering is left to the client.
+type fakeServer struct {
+	*httptest.Server
+	mutex           sync.Mutex
+	acls            map[string][]ACLListResponse
+	serviceAccounts []ServiceAccount
+	// identityPools are listed by identity provider, one pool per page
+	identityPools map[string][]IdentityPool
+	// failStatus, when set, is the status of every mutating request
+	failStatus int
+	// extraDeleted are reported as deleted by every ACL deletion, like a REST proxy deleting more than asked would
+	extraDeleted []ACLListResponse
+	requests     int
+}
+
+const (
+	testCloudApiSecret = "test-cloud-api-secret"
+	testApiKeySecret   = "test-api-key-secret"
+)
+
+func newFakeServer(t *testing.T) (*fakeServer, *Client) {
+	server := &fakeServer{acls: make(map[string][]ACLListResponse), identityPools: make(map[string][]IdentityPool)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	t.Cleanup(server.Close)
+
+	c := New("key", testCloudApiSecret)
+	c.SetApiUrl(server.URL + "/")
+	return server, c
+}
+
+func (s *fakeServer) addAcls(clusterId string, acls ...ACLListResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.acls[clusterId] = append(s.acls[clusterId], acls...)
+}
+
+func (s *fakeServer) addServiceAccounts(serviceAccounts ...ServiceAccount) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.serviceAccounts = append(s.serviceAccounts, serviceAccounts...)
+}
+
+func (s *fakeServer) addIdentityPools(providerId string, identityPools ...IdentityPool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.identityPools[providerId] = append(s.identityPools[providerId], identityPools...)
+}
+
+func (s *fakeServer) failMutations(status int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.failStatus = status
+}
+
+func (s *fakeServer) reportExtraDeletedAcls(acls ...ACLListResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.extraDeleted = acls
+}
+
+func (s *fakeServer) clusterAcls(clusterId string) []ACLListResponse {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]ACLListResponse(nil), s.acls[clusterId]...)
+}
+
+func (s *fakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requests++
+	w.Header().Set(requestIdHeader, fmt.Sprintf("request-%d", s.requests))
+
+	if r.URL.Path == "/access_tokens" {
+		claims, _ := json.Marshal(JwtToken{Exp: time.Now().Add(time.Hour).Unix()})
+		writeJSON(w, http.StatusOK, AccessTokenResponse{Token: "header." + base64.RawStdEncoding.EncodeToString(claims) + ".signature"})
+		return
+	}
+
+	if s.failStatus != 0 && r.Method != http.MethodGet {
+		w.WriteHeader(s.failStatus)
+		return
+	}
+	if r.URL.Path == "/api_keys" && r.Method == http.MethodPost {
+		var body ApiKeyCreateRequestW
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, http.StatusOK, ApiKeyResponseInternal{ApiKey: ApiKeyInternal{
+			Key:    "TESTKEY",
+			Secret: testApiKeySecret,
+			ID:     s.requests,
+			UserID: body.ApiKey.UserID,
+		}})
+		return
+	}
+
+	if r.URL.Path == "/service_accounts" {
+		writeJSON(w, http.StatusOK, ServiceAccountResponse{Users: s.serviceAccounts})
+		return
+	}
+	if r.URL.Path == "/iam/v2/identity-providers" {
+		response := iamV2ListResponse[identityProvider]{}
+		for providerId := range s.identityPools {
+			response.Data = append(response.Data, identityProvider{Id: providerId})
+		}
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+	if providerPath, ok := strings.CutPrefix(r.URL.Path, "/iam/v2/identity-providers/"); ok {
+		pools := s.identityPools[strings.TrimSuffix(providerPath, "/identity-pools")]
+		page, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+		response := iamV2ListResponse[IdentityPool]{}
+		if page < len(pools) {
+			response.Data = pools[page : page+1]
+		}
+		if page+1 < len(pools) {
+			response.Metadata.Next = fmt.Sprintf("%s%s?page_token=%d", s.URL, r.URL.Path, page+1)
+		}
+		writeJSON(w, http.StatusOK, response)
+		return
+	}
+
+	var clusterId string
+	if _, err := fmt.Sscanf(strings.TrimSuffix(r.URL.Path, "/acls"), "/kafka/v3/clusters/%s", &clusterId); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if !strings.HasSuffix(r.URL.Path, "/acls") {
+		if _, ok := s.acls[clusterId]; !ok || r.Method != http.MethodGet {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"kind": "KafkaCluster", "cluster_id": clusterId})
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, ACLListResponseWrapper{Kind: "KafkaAclDataList", Data: s.acls[clusterId]})
+	case http.MethodPost:
+		var acl ACLRequest
+		if err := json.NewDecoder(r.Body).Decode(&acl); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.acls[clusterId] = append(s.acls[clusterId], aclListResponse(clusterId, &acl))
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		params := r.URL.Query()
+		filter := &ACLRequest{
+			Principal:    params.Get("principal"),
+			ResourceType: params.Get("resource_type"),
+			ResourceName: params.Get("resource_name"),
+			PatternType:  params.Get("pattern_type"),
+			Host:         params.Get("host"),
+			Operation:    params.Get("operation"),
+			Permission:   params.Get("permission"),
+		}
+		var kept, deleted []ACLListResponse
+		for _, acl := range s.acls[clusterId] {
+			if filter.Matches(acl) {
+				deleted = append(deleted, acl)
+			} else {
+				kept = append(kept, acl)
+			}
+		}
+		s.acls[clusterId] = kept
+		deleted = append(deleted, s.extraDeleted...)
+		writeJSON(w, http.StatusOK, ACLListResponseWrapper{Kind: "KafkaAclDataList", Data: deleted})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func aclListResponse(clusterId string, acl *ACLRequest) ACLListResponse {
+	return ACLListResponse{
+		Kind:         "KafkaAcl",
+		ClusterId:    clusterId,
+		ResourceType: acl.ResourceType,
+		ResourceName: acl.ResourceName,
+		PatternType:  acl.PatternType,
+		Principal:    acl.Principal,
+		Host:         acl.Host,
+		Operation:    acl.Operation,
+		Permission:   acl.Permission,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
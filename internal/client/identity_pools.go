@@ -0,0 +1,90 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"terraform-provider-confluentacl/internal/client/request"
+)
+
+const (
+	identityProvidersEndpoint = "iam/v2/identity-providers"
+	identityPoolsEndpoint     = "iam/v2/identity-providers/%s/identity-pools"
+)
+
+type IdentityPool struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"display_name"`
+}
+
+type identityProvider struct {
+	Id string `json:"id"`
+}
+
+type iamV2ListResponse[T any] struct {
+	Data     []T `json:"data"`
+	Metadata struct {
+		Next string `json:"next"`
+	} `json:"metadata"`
+}
+
+// ListIdentityPools returns the identity pools of every identity provider of the organization
+func (c *Client) ListIdentityPools() ([]IdentityPool, error) {
+	c.cacheMutex.RLock()
+	identityPoolsCache, ok := c.cache["identityPools"]
+	c.cacheMutex.RUnlock()
+	if ok {
+		return identityPoolsCache.([]IdentityPool), nil
+	}
+
+	providers, err := listIamV2[identityProvider](c, identityProvidersEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("listing identity providers failed: %w", err)
+	}
+	identityPools := make([]IdentityPool, 0)
+	for _, provider := range providers {
+		pools, err := listIamV2[IdentityPool](c, fmt.Sprintf(identityPoolsEndpoint, provider.Id))
+		if err != nil {
+			return nil, fmt.Errorf("listing identity pools of %s failed: %w", provider.Id, err)
+		}
+		identityPools = append(identityPools, pools...)
+	}
+
+	c.cacheMutex.Lock()
+	c.cache["identityPools"] = identityPools
+	c.cacheMutex.Unlock()
+	return identityPools, nil
+}
+
+// listIamV2 returns every item of an iam/v2 list endpoint, following the page token of metadata.next
+func listIamV2[T any](c *Client, endpoint string) ([]T, error) {
+	var items []T
+	queryParams := map[string]string{"page_size": "100"}
+	for {
+		response, err := c.RequestBuilder().Endpoint(endpoint).SetQueryParams(queryParams).Get().ExecuteAndRetryOn429()
+		if err != nil {
+			return nil, err
+		}
+		if response.StatusCode != 200 {
+			response.Body.Close()
+			return nil, fmt.Errorf("response status: %s", response.Status)
+		}
+		var page iamV2ListResponse[T]
+		err = request.UnpackJSONResponse(response, &page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+		if page.Metadata.Next == "" {
+			return items, nil
+		}
+		nextUrl, err := url.Parse(page.Metadata.Next)
+		if err != nil {
+			return nil, err
+		}
+		pageToken := nextUrl.Query().Get("page_token")
+		if pageToken == "" {
+			return items, nil
+		}
+		queryParams["page_token"] = pageToken
+	}
+}
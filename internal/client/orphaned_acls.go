@@ -0,0 +1,64 @@
+package client
+
+import (
+	"strconv"
+	"strings"
+)
+
+// OrphanedPrincipalKind returns what an orphaned principal refers to: "service_account" for resource ids
+// (User:sa-123abc), "identity_pool" for User:pool-123abc, "unresolved_numeric" for legacy numeric ids (User:12345), or
+// "" for principals that aren't checked (user accounts, User:* and groups). Numeric ids are resolved against the
+// service accounts, but user accounts have numeric ids too and can't be listed, so a numeric id matching no service
+// account is reported apart: it's most likely a deleted service account, but may be a live user.
+func OrphanedPrincipalKind(principal string) string {
+	userRef, isUser := strings.CutPrefix(principal, "User:")
+	if !isUser {
+		return ""
+	}
+	if strings.HasPrefix(userRef, "sa-") {
+		return "service_account"
+	}
+	if strings.HasPrefix(userRef, "pool-") {
+		return "identity_pool"
+	}
+	if _, err := strconv.Atoi(userRef); err == nil {
+		return "unresolved_numeric"
+	}
+	return ""
+}
+
+// OrphanedAcls returns the ACLs whose principal is a service account, numeric id or identity pool that no longer
+// exists.
+// Identity pools are only listed when an ACL references one.
+func (c *Client) OrphanedAcls(acls []ACLListResponse) ([]ACLListResponse, error) {
+	serviceAccounts, err := c.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool, 2*len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		existing["User:"+serviceAccount.Id] = true
+		existing["User:"+strconv.Itoa(serviceAccount.UserId)] = true
+	}
+	for _, acl := range acls {
+		if OrphanedPrincipalKind(acl.Principal) != "identity_pool" {
+			continue
+		}
+		identityPools, err := c.ListIdentityPools()
+		if err != nil {
+			return nil, err
+		}
+		for _, identityPool := range identityPools {
+			existing["User:"+identityPool.Id] = true
+		}
+		break
+	}
+
+	orphaned := make([]ACLListResponse, 0)
+	for _, acl := range acls {
+		if OrphanedPrincipalKind(acl.Principal) != "" && !existing[acl.Principal] {
+			orphaned = append(orphaned, acl)
+		}
+	}
+	return orphaned, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrphanedAcls(t *testing.T) {
+	server, c := newFakeServer(t)
+	server.addServiceAccounts(ServiceAccount{Id: "sa-live", UserId: 100, ServiceName: "live"})
+	server.addIdentityPools("op-1", IdentityPool{Id: "pool-a"}, IdentityPool{Id: "pool-live"})
+
+	live := []ACLListResponse{
+		testAcl("User:sa-live", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:100", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:pool-live", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:u-human", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:*", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("Group:admins", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+	}
+	orphaned := []ACLListResponse{
+		testAcl("User:sa-deleted", "TOPIC", "orders", "LITERAL", "READ", "ALLOW"),
+		testAcl("User:200", "GROUP", "orders", "PREFIXED", "READ", "ALLOW"),
+		testAcl("User:pool-deleted", "TOPIC", "orders", "LITERAL", "WRITE", "ALLOW"),
+	}
+
+	got, err := c.OrphanedAcls(append(append([]ACLListResponse(nil), live...), orphaned...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, orphaned) {
+		t.Errorf("expected orphaned ACLs %v, got %v", orphaned, got)
+	}
+}
+
+func TestOrphanedPrincipalKind(t *testing.T) {
+	tests := map[string]string{
+		"User:sa-123abc":   "service_account",
+		"User:pool-123abc": "identity_pool",
+		"User:12345":       "unresolved_numeric",
+		"User:u-123abc":    "",
+		"User:*":           "",
+		"User:12345abc":    "",
+		"Group:admins":     "",
+	}
+	for principal, want := range tests {
+		if got := OrphanedPrincipalKind(principal); got != want {
+			t.Errorf("OrphanedPrincipalKind(%q) = %q, want %q", principal, got, want)
+		}
+	}
+}
+
+func TestListIdentityPoolsFollowsPages(t *testing.T) {
+	server, c := newFakeServer(t)
+	server.addIdentityPools("op-1", IdentityPool{Id: "pool-a"}, IdentityPool{Id: "pool-b"}, IdentityPool{Id: "pool-c"})
+
+	pools, err := c.ListIdentityPools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pools) != 3 {
+		t.Errorf("expected the 3 pools of every page, got %v", pools)
+	}
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"terraform-provider-confluentacl/internal/client/request"
 )
 
@@ -49,16 +51,79 @@ func (c *Client) ListServiceAccounts() ([]ServiceAccount, error) {
 	return serviceAccounts.Users, nil
 }
 
+// GetSaNumericId returns the legacy numeric id of the service account with the given name
 func (c *Client) GetSaNumericId(saName string) (int, error) {
-	serviceAccountList, err := c.ListServiceAccounts()
+	serviceAccount, err := c.GetServiceAccount(saName)
 	if err != nil {
 		return 0, err
 	}
-	var numericUserId int
+	if serviceAccount == nil {
+		return 0, fmt.Errorf("could not find service account with name %s", saName)
+	}
+	return serviceAccount.UserId, nil
+}
+
+// GetServiceAccount returns the service account with the given name, or nil if there's none
+func (c *Client) GetServiceAccount(saName string) (*ServiceAccount, error) {
+	serviceAccountList, err := c.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
 	for _, serviceAccount := range serviceAccountList {
 		if serviceAccount.ServiceName == saName {
-			numericUserId = serviceAccount.UserId
+			return &serviceAccount, nil
+		}
+	}
+	return nil, nil
+}
+
+// PrincipalAliases returns every form a principal can be listed with in kafka ACLs, starting with the given one.
+// Service accounts can be referenced by resource id (User:sa-123abc) or by the legacy numeric id (User:12345).
+func (c *Client) PrincipalAliases(principal string) ([]string, error) {
+	userRef, isUser := strings.CutPrefix(principal, "User:")
+	numericId, numericErr := strconv.Atoi(userRef)
+	if !isUser || (numericErr != nil && !strings.HasPrefix(userRef, "sa-")) {
+		return []string{principal}, nil
+	}
+	serviceAccountList, err := c.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceAccount := range serviceAccountList {
+		if serviceAccount.Id == userRef {
+			return []string{principal, fmt.Sprintf("User:%d", serviceAccount.UserId)}, nil
 		}
+		if numericErr == nil && serviceAccount.UserId == numericId {
+			return []string{principal, "User:" + serviceAccount.Id}, nil
+		}
+	}
+	return []string{principal}, nil
+}
+
+// GetServiceAccountById returns the service account with the given resource id (sa-123abc), or nil if there's none
+func (c *Client) GetServiceAccountById(id string) (*ServiceAccount, error) {
+	serviceAccountList, err := c.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceAccount := range serviceAccountList {
+		if serviceAccount.Id == id {
+			return &serviceAccount, nil
+		}
+	}
+	return nil, nil
+}
+
+// LegacyPrincipalAliases maps the legacy numeric principal of every service account (User:12345) to its resource id
+// principal (User:sa-123abc)
+func (c *Client) LegacyPrincipalAliases() (map[string]string, error) {
+	serviceAccountList, err := c.ListServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+	aliases := make(map[string]string, len(serviceAccountList))
+	for _, serviceAccount := range serviceAccountList {
+		aliases[fmt.Sprintf("User:%d", serviceAccount.UserId)] = "User:" + serviceAccount.Id
 	}
-	return numericUserId, nil
+	return aliases, nil
 }
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &AccessCheckDataSource{}
+	_ datasource.DataSourceWithConfigure = &AccessCheckDataSource{}
+)
+
+// AccessCheckDataSource answers whether a principal can perform an operation on a resource, given the ACLs of a cluster
+type AccessCheckDataSource struct {
+	client *client.Client
+}
+
+type AccessCheckDataSourceModel struct {
+	ID                 types.String       `tfsdk:"id"`
+	RestEndpoint       types.String       `tfsdk:"rest_endpoint"`
+	ClusterId          types.String       `tfsdk:"cluster_id"`
+	ServiceAccountName types.String       `tfsdk:"service_account_name"`
+	Principal          types.String       `tfsdk:"principal"`
+	Host               types.String       `tfsdk:"host"`
+	Operation          types.String       `tfsdk:"operation"`
+	ResourceType       types.String       `tfsdk:"resource_type"`
+	ResourceName       types.String       `tfsdk:"resource_name"`
+	Allowed            types.Bool         `tfsdk:"allowed"`
+	Reason             types.String       `tfsdk:"reason"`
+	DecidingAcls       []AclDataSourceAcl `tfsdk:"deciding_acls"`
+}
+
+func NewAccessCheckDataSource() datasource.DataSource {
+	return &AccessCheckDataSource{}
+}
+
+func (r *AccessCheckDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_check"
+}
+
+func (r *AccessCheckDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*providerData).client
+}
+
+func (r *AccessCheckDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	// Clients request concrete operations, ALL only exists in ACLs
+	var requestOperations []string
+	for _, operation := range aclOperations {
+		if operation != client.AclOperationAll {
+			requestOperations = append(requestOperations, operation)
+		}
+	}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"host": schema.StringAttribute{
+				Optional: true,
+			},
+			"operation": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{stringvalidator.OneOf(requestOperations...)},
+			},
+			"resource_type": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{stringvalidator.OneOf(aclResourceTypes...)},
+			},
+			"resource_name": schema.StringAttribute{
+				Required: true,
+			},
+			"allowed": schema.BoolAttribute{
+				Computed: true,
+			},
+			"reason": schema.StringAttribute{
+				Computed: true,
+			},
+			"deciding_acls": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal":     schema.StringAttribute{Computed: true},
+						"resource_type": schema.StringAttribute{Computed: true},
+						"resource_name": schema.StringAttribute{Computed: true},
+						"pattern_type":  schema.StringAttribute{Computed: true},
+						"host":          schema.StringAttribute{Computed: true},
+						"operation":     schema.StringAttribute{Computed: true},
+						"permission":    schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AccessCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state AccessCheckDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("service_account_name"), "Failed to resolve service account", err.Error())
+		return
+	}
+	principals, err := r.client.PrincipalAliases(principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		return
+	}
+	acls, err := r.client.ListACLs(state.RestEndpoint.ValueString(), state.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	host := "*"
+	if !state.Host.IsNull() {
+		host = state.Host.ValueString()
+	}
+	decision := client.EvaluateAccess(acls, &client.AccessRequest{
+		Principals:   principals,
+		Host:         host,
+		Operation:    state.Operation.ValueString(),
+		ResourceType: state.ResourceType.ValueString(),
+		ResourceName: state.ResourceName.ValueString(),
+	})
+	sortAcls(decision.DecidingAcls)
+
+	state.ID = types.StringValue(strings.Join([]string{
+		state.ClusterId.ValueString(), principal, state.Operation.ValueString(), state.ResourceType.ValueString(), state.ResourceName.ValueString(),
+	}, "/"))
+	state.Allowed = types.BoolValue(decision.Allowed)
+	state.Reason = types.StringValue(decision.Reason)
+	state.DecidingAcls = aclDataSourceAcls(decision.DecidingAcls)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccessCheckDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessCheckDataSourceConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					// READ implies DESCRIBE
+					resource.TestCheckResourceAttr("data.confluentacl_access_check.describe", "allowed", "true"),
+					resource.TestCheckResourceAttr("data.confluentacl_access_check.describe", "deciding_acls.#", "1"),
+					resource.TestCheckResourceAttr("data.confluentacl_access_check.describe", "deciding_acls.0.operation", "READ"),
+					resource.TestCheckResourceAttr("data.confluentacl_access_check.write", "allowed", "false"),
+					resource.TestCheckResourceAttr("data.confluentacl_access_check.write", "deciding_acls.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAccessCheckDataSourceConfig(saName, clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-check"
+			pattern_type  = "PREFIXED"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+		}
+
+		data "confluentacl_access_check" "describe" {
+			service_account_name = confluentacl_acl.example.service_account_name
+			cluster_id           = confluentacl_acl.example.cluster_id
+			rest_endpoint        = confluentacl_acl.example.rest_endpoint
+			operation            = "DESCRIBE"
+			resource_type        = "TOPIC"
+			resource_name        = "${confluentacl_acl.example.resource_name}-orders"
+		}
+
+		data "confluentacl_access_check" "write" {
+			service_account_name = confluentacl_acl.example.service_account_name
+			cluster_id           = confluentacl_acl.example.cluster_id
+			rest_endpoint        = confluentacl_acl.example.rest_endpoint
+			operation            = "WRITE"
+			resource_type        = "TOPIC"
+			resource_name        = "${confluentacl_acl.example.resource_name}-orders"
+		}
+		`, saName, clusterId, restEndpoint)
+}
@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &AclAnalysisDataSource{}
+	_ datasource.DataSourceWithConfigure = &AclAnalysisDataSource{}
+)
+
+// AclAnalysisDataSource reports the ACLs of a cluster that can be deleted without changing access
+type AclAnalysisDataSource struct {
+	client *client.Client
+}
+
+type AclAnalysisDataSourceModel struct {
+	ID                 types.String      `tfsdk:"id"`
+	RestEndpoint       types.String      `tfsdk:"rest_endpoint"`
+	ClusterId          types.String      `tfsdk:"cluster_id"`
+	ServiceAccountName types.String      `tfsdk:"service_account_name"`
+	Principal          types.String      `tfsdk:"principal"`
+	Findings           []AclFindingModel `tfsdk:"findings"`
+}
+
+type AclFindingModel struct {
+	Kind      types.String     `tfsdk:"kind"`
+	Principal types.String     `tfsdk:"principal"`
+	Detail    types.String     `tfsdk:"detail"`
+	Acl       AclDataSourceAcl `tfsdk:"acl"`
+	CoveredBy AclDataSourceAcl `tfsdk:"covered_by"`
+}
+
+func NewAclAnalysisDataSource() datasource.DataSource {
+	return &AclAnalysisDataSource{}
+}
+
+func (r *AclAnalysisDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_analysis"
+}
+
+func (r *AclAnalysisDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*providerData).client
+}
+
+func (r *AclAnalysisDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	aclAttributes := func() map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"principal":     schema.StringAttribute{Computed: true},
+			"resource_type": schema.StringAttribute{Computed: true},
+			"resource_name": schema.StringAttribute{Computed: true},
+			"pattern_type":  schema.StringAttribute{Computed: true},
+			"host":          schema.StringAttribute{Computed: true},
+			"operation":     schema.StringAttribute{Computed: true},
+			"permission":    schema.StringAttribute{Computed: true},
+		}
+	}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"findings": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"kind":      schema.StringAttribute{Computed: true},
+						"principal": schema.StringAttribute{Computed: true},
+						"detail":    schema.StringAttribute{Computed: true},
+						"acl": schema.SingleNestedAttribute{
+							Computed:   true,
+							Attributes: aclAttributes(),
+						},
+						"covered_by": schema.SingleNestedAttribute{
+							Computed:   true,
+							Attributes: aclAttributes(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AclAnalysisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state AclAnalysisDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	aliases, err := r.client.LegacyPrincipalAliases()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		return
+	}
+	principal := ""
+	if !state.ServiceAccountName.IsNull() || !state.Principal.IsNull() {
+		principal, err = resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("service_account_name"), "Failed to resolve service account", err.Error())
+			return
+		}
+		if alias, ok := aliases[principal]; ok {
+			principal = alias
+		}
+	}
+	acls, err := r.client.ListACLs(state.RestEndpoint.ValueString(), state.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	// Sorted, so the ACL kept of two duplicates doesn't change between reads
+	sortAcls(acls)
+
+	state.ID = types.StringValue(state.ClusterId.ValueString())
+	state.Findings = make([]AclFindingModel, 0)
+	for _, finding := range client.AnalyzeAcls(acls, aliases) {
+		if principal != "" && finding.Principal != principal {
+			continue
+		}
+		findingAcls := aclDataSourceAcls([]client.ACLListResponse{finding.Acl, finding.CoveredBy})
+		state.Findings = append(state.Findings, AclFindingModel{
+			Kind:      types.StringValue(finding.Kind),
+			Principal: types.StringValue(finding.Principal),
+			Detail:    types.StringValue(finding.Detail),
+			Acl:       findingAcls[0],
+			CoveredBy: findingAcls[1],
+		})
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
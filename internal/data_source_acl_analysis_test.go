@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAclAnalysisDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclAnalysisDataSourceConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.confluentacl_acl_analysis.example", "findings.#", "1"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_analysis.example", "findings.0.kind", "shadowed"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_analysis.example", "findings.0.acl.pattern_type", "LITERAL"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_analysis.example", "findings.0.covered_by.pattern_type", "PREFIXED"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAclAnalysisDataSourceConfig(saName, clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_principal_acls" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			acl = [
+				{
+					resource_type = "TOPIC"
+					resource_name = "terraform-provider-confluentacl-analysis"
+					pattern_type  = "PREFIXED"
+					host          = "*"
+					operation     = "READ"
+					permission    = "ALLOW"
+				},
+				{
+					resource_type = "TOPIC"
+					resource_name = "terraform-provider-confluentacl-analysis-orders"
+					pattern_type  = "LITERAL"
+					host          = "*"
+					operation     = "READ"
+					permission    = "ALLOW"
+				},
+			]
+		}
+
+		data "confluentacl_acl_analysis" "example" {
+			principal     = confluentacl_principal_acls.example.principal
+			cluster_id    = confluentacl_principal_acls.example.cluster_id
+			rest_endpoint = confluentacl_principal_acls.example.rest_endpoint
+		}
+		`, saName, clusterId, restEndpoint)
+}
@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource = &AclPolicyFileDataSource{}
+)
+
+// AclPolicyFileDataSource turns the access requests of application teams, kept as YAML or JSON in their repositories,
+// into ACLs for confluentacl_acl resources. It only parses the file, so it needs no access to the clusters.
+type AclPolicyFileDataSource struct{}
+
+type AclPolicyFileDataSourceModel struct {
+	ID      types.String                      `tfsdk:"id"`
+	Content types.String                      `tfsdk:"content"`
+	Version types.Int64                       `tfsdk:"version"`
+	Acls    map[string]AclPolicyFileAclsModel `tfsdk:"acls"`
+}
+
+type AclPolicyFileAclsModel struct {
+	Name               types.String `tfsdk:"name"`
+	ServiceAccountName types.String `tfsdk:"service_account_name"`
+	Principal          types.String `tfsdk:"principal"`
+	ResourceType       types.String `tfsdk:"resource_type"`
+	ResourceName       types.String `tfsdk:"resource_name"`
+	PatternType        types.String `tfsdk:"pattern_type"`
+	Host               types.String `tfsdk:"host"`
+	Operation          types.String `tfsdk:"operation"`
+	Permission         types.String `tfsdk:"permission"`
+}
+
+func NewAclPolicyFileDataSource() datasource.DataSource {
+	return &AclPolicyFileDataSource{}
+}
+
+func (r *AclPolicyFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_policy_file"
+}
+
+func (r *AclPolicyFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"content": schema.StringAttribute{
+				Required: true,
+			},
+			"version": schema.Int64Attribute{
+				Computed: true,
+			},
+			"acls": schema.MapNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name":                 schema.StringAttribute{Computed: true},
+						"service_account_name": schema.StringAttribute{Computed: true},
+						"principal":            schema.StringAttribute{Computed: true},
+						"resource_type":        schema.StringAttribute{Computed: true},
+						"resource_name":        schema.StringAttribute{Computed: true},
+						"pattern_type":         schema.StringAttribute{Computed: true},
+						"host":                 schema.StringAttribute{Computed: true},
+						"operation":            schema.StringAttribute{Computed: true},
+						"permission":           schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AclPolicyFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state AclPolicyFileDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	file, errs := parseAclPolicyFile(state.Content.ValueString())
+	for _, err := range errs {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid ACL policy file", err.Error())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys are stable across runs, so for_each only changes the ACLs that changed in the file
+	state.Acls = make(map[string]AclPolicyFileAclsModel)
+	for _, principal := range file.Principals {
+		serviceAccountName, kafkaPrincipal := types.StringNull(), types.StringNull()
+		if principal.ServiceAccount != "" {
+			serviceAccountName = types.StringValue(principal.ServiceAccount)
+		} else {
+			kafkaPrincipal = types.StringValue(principal.Principal)
+		}
+		for _, rule := range principal.rules() {
+			state.Acls[principal.Name+"/"+rule.key()] = AclPolicyFileAclsModel{
+				Name:               types.StringValue(principal.Name),
+				ServiceAccountName: serviceAccountName,
+				Principal:          kafkaPrincipal,
+				ResourceType:       rule.ResourceType,
+				ResourceName:       rule.ResourceName,
+				PatternType:        rule.PatternType,
+				Host:               rule.Host,
+				Operation:          rule.Operation,
+				Permission:         rule.Permission,
+			}
+		}
+	}
+	state.ID = types.StringValue(fmt.Sprintf("%x", sha256.Sum256([]byte(state.Content.ValueString()))))
+	state.Version = types.Int64Value(int64(file.Version))
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,74 @@
+package internal
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAclPolicyFileDataSource(t *testing.T) {
+	// The data source only parses the file, so no credentials or test cluster are needed
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclPolicyFileDataSourceConfig(`
+version: 1
+principals:
+  - name: orders-app
+    service_account: orders-app
+    roles: [producer, consumer]
+    topics: [orders.]
+    groups: [orders-app.]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "version", "1"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "acls.%", "6"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "acls.orders-app/TOPIC#orders.#PREFIXED#*#WRITE#ALLOW.service_account_name", "orders-app"),
+					resource.TestCheckNoResourceAttr("data.confluentacl_acl_policy_file.example", "acls.orders-app/TOPIC#orders.#PREFIXED#*#WRITE#ALLOW.principal"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "acls.orders-app/GROUP#orders-app.#PREFIXED#*#READ#ALLOW.operation", "READ"),
+				),
+			},
+			{
+				Config: testAccAclPolicyFileDataSourceConfig(`{"version": 1, "principals": [{"name": "all", "principal": "User:*", "roles": ["producer"], "topics": ["public."]}]}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "acls.%", "3"),
+					resource.TestCheckResourceAttr("data.confluentacl_acl_policy_file.example", "acls.all/CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW.principal", "User:*"),
+				),
+			},
+			{
+				Config: testAccAclPolicyFileDataSourceConfig(`
+version: 1
+principals:
+  - name: orders-app
+    service_account: orders-app
+    roles: [writer]
+    topics: [orders.]
+`),
+				ExpectError: regexp.MustCompile(`line 6, column 13: unknown role "writer"`),
+			},
+		},
+	})
+}
+
+func testAccAclPolicyFileDataSourceConfig(content string) string {
+	return fmt.Sprintf(`
+		provider "confluentacl" {
+			confluent_cloud_api_key    = "unused"
+			confluent_cloud_api_secret = "unused"
+		}
+
+		data "confluentacl_acl_policy_file" "example" {
+			content = <<-EOT
+%s
+			EOT
+		}
+		`, content)
+}
@@ -0,0 +1,203 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &AclsDataSource{}
+	_ datasource.DataSourceWithConfigure = &AclsDataSource{}
+)
+
+type AclsDataSource struct {
+	client *client.Client
+}
+
+type AclsDataSourceModel struct {
+	ID                 types.String       `tfsdk:"id"`
+	RestEndpoint       types.String       `tfsdk:"rest_endpoint"`
+	ClusterId          types.String       `tfsdk:"cluster_id"`
+	ServiceAccountName types.String       `tfsdk:"service_account_name"`
+	Principal          types.String       `tfsdk:"principal"`
+	ResourceType       types.String       `tfsdk:"resource_type"`
+	ResourceName       types.String       `tfsdk:"resource_name"`
+	PatternType        types.String       `tfsdk:"pattern_type"`
+	Host               types.String       `tfsdk:"host"`
+	Operation          types.String       `tfsdk:"operation"`
+	Permission         types.String       `tfsdk:"permission"`
+	Acls               []AclDataSourceAcl `tfsdk:"acls"`
+}
+
+type AclDataSourceAcl struct {
+	Principal    types.String `tfsdk:"principal"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	PatternType  types.String `tfsdk:"pattern_type"`
+	Host         types.String `tfsdk:"host"`
+	Operation    types.String `tfsdk:"operation"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+// aclDataSourceAcls converts listed ACLs to the nested objects of data sources
+func aclDataSourceAcls(acls []client.ACLListResponse) []AclDataSourceAcl {
+	result := make([]AclDataSourceAcl, 0, len(acls))
+	for _, acl := range acls {
+		result = append(result, AclDataSourceAcl{
+			Principal:    types.StringValue(acl.Principal),
+			ResourceType: types.StringValue(acl.ResourceType),
+			ResourceName: types.StringValue(acl.ResourceName),
+			PatternType:  types.StringValue(acl.PatternType),
+			Host:         types.StringValue(acl.Host),
+			Operation:    types.StringValue(acl.Operation),
+			Permission:   types.StringValue(acl.Permission),
+		})
+	}
+	return result
+}
+
+func NewAclsDataSource() datasource.DataSource {
+	return &AclsDataSource{}
+}
+
+func (r *AclsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acls"
+}
+
+func (r *AclsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*providerData).client
+}
+
+func (r *AclsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(aclFilterResourceTypes...)},
+			},
+			"resource_name": schema.StringAttribute{
+				Optional: true,
+			},
+			"pattern_type": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(aclFilterPatternTypes...)},
+			},
+			"host": schema.StringAttribute{
+				Optional: true,
+			},
+			"operation": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(aclFilterOperations...)},
+			},
+			"permission": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf(aclFilterPermissions...)},
+			},
+			"acls": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal":     schema.StringAttribute{Computed: true},
+						"resource_type": schema.StringAttribute{Computed: true},
+						"resource_name": schema.StringAttribute{Computed: true},
+						"pattern_type":  schema.StringAttribute{Computed: true},
+						"host":          schema.StringAttribute{Computed: true},
+						"operation":     schema.StringAttribute{Computed: true},
+						"permission":    schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *AclsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state AclsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal := state.Principal.ValueString()
+	if !state.ServiceAccountName.IsNull() {
+		var err error
+		principal, err = resolveServiceAccountPrincipal(r.client, state.ServiceAccountName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("service_account_name"), "Failed to resolve service account", err.Error())
+			return
+		}
+	}
+	// Without a principal filter a single query lists every principal
+	principals := []string{""}
+	if principal != "" {
+		var err error
+		principals, err = r.client.PrincipalAliases(principal)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+			return
+		}
+	}
+
+	var aclsFound []client.ACLListResponse
+	for _, principal := range principals {
+		acls, err := r.client.ListSpecificACLs(state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), &client.ACLRequest{
+			Principal:    principal,
+			ResourceType: state.ResourceType.ValueString(),
+			ResourceName: state.ResourceName.ValueString(),
+			PatternType:  state.PatternType.ValueString(),
+			Host:         state.Host.ValueString(),
+			Operation:    state.Operation.ValueString(),
+			Permission:   state.Permission.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+			return
+		}
+		aclsFound = append(aclsFound, acls...)
+	}
+	sortAcls(aclsFound)
+
+	state.ID = types.StringValue(state.ClusterId.ValueString())
+	state.Acls = aclDataSourceAcls(aclsFound)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAclsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclsDataSourceConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.confluentacl_acls.example", "id", testRealResource.ClusterId),
+					resource.TestCheckResourceAttr("data.confluentacl_acls.example", "acls.#", "1"),
+					resource.TestCheckResourceAttr("data.confluentacl_acls.example", "acls.0.resource_name", "terraform-provider-confluentacl-test"),
+					resource.TestCheckResourceAttr("data.confluentacl_acls.example", "acls.0.operation", "READ"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAclsDataSourceConfig(saName, clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-test"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+		}
+
+		data "confluentacl_acls" "example" {
+			service_account_name = confluentacl_acl.example.service_account_name
+			cluster_id           = confluentacl_acl.example.cluster_id
+			rest_endpoint        = confluentacl_acl.example.rest_endpoint
+			resource_type        = "TOPIC"
+			resource_name        = confluentacl_acl.example.resource_name
+			pattern_type         = "LITERAL"
+		}
+		`, saName, clusterId, restEndpoint)
+}
@@ -0,0 +1,122 @@
+package internal
+
+import (
+	"context"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &OrphanedAclsDataSource{}
+	_ datasource.DataSourceWithConfigure = &OrphanedAclsDataSource{}
+)
+
+// OrphanedAclsDataSource lists the ACLs left behind by deleted service accounts and identity pools
+type OrphanedAclsDataSource struct {
+	client *client.Client
+}
+
+type OrphanedAclsDataSourceModel struct {
+	ID             types.String       `tfsdk:"id"`
+	RestEndpoint   types.String       `tfsdk:"rest_endpoint"`
+	ClusterId      types.String       `tfsdk:"cluster_id"`
+	Principals     []string           `tfsdk:"principals"`
+	PrincipalKinds map[string]string  `tfsdk:"principal_kinds"`
+	Acls           []AclDataSourceAcl `tfsdk:"acls"`
+}
+
+func NewOrphanedAclsDataSource() datasource.DataSource {
+	return &OrphanedAclsDataSource{}
+}
+
+func (r *OrphanedAclsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_orphaned_acls"
+}
+
+func (r *OrphanedAclsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*providerData).client
+}
+
+func (r *OrphanedAclsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"principals": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"principal_kinds": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"acls": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal":     schema.StringAttribute{Computed: true},
+						"resource_type": schema.StringAttribute{Computed: true},
+						"resource_name": schema.StringAttribute{Computed: true},
+						"pattern_type":  schema.StringAttribute{Computed: true},
+						"host":          schema.StringAttribute{Computed: true},
+						"operation":     schema.StringAttribute{Computed: true},
+						"permission":    schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *OrphanedAclsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state OrphanedAclsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	acls, err := r.client.ListACLs(state.RestEndpoint.ValueString(), state.ClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	orphaned, err := r.client.OrphanedAcls(acls)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principals", err.Error())
+		return
+	}
+	sortAcls(orphaned)
+
+	state.ID = types.StringValue(state.ClusterId.ValueString())
+	state.Principals = make([]string, 0)
+	state.PrincipalKinds = make(map[string]string)
+	for _, acl := range orphaned {
+		if !containsString(state.Principals, acl.Principal) {
+			state.Principals = append(state.Principals, acl.Principal)
+			state.PrincipalKinds[acl.Principal] = client.OrphanedPrincipalKind(acl.Principal)
+		}
+	}
+	state.Acls = aclDataSourceAcls(orphaned)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOrphanedAclsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrphanedAclsDataSourceConfig(testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.confluentacl_orphaned_acls.example", "id", testRealResource.ClusterId),
+					resource.TestCheckTypeSetElemAttr("data.confluentacl_orphaned_acls.example", "principals.*", "User:sa-terraformdeleted"),
+					resource.TestCheckResourceAttr("data.confluentacl_orphaned_acls.example", "principal_kinds.User:sa-terraformdeleted", "service_account"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.confluentacl_orphaned_acls.example", "acls.*", map[string]string{
+						"principal":     "User:sa-terraformdeleted",
+						"resource_name": "terraform-provider-confluentacl-test",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccOrphanedAclsDataSourceConfig(clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			principal     = "User:sa-terraformdeleted"
+			cluster_id    = "%s"
+			rest_endpoint = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-test"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+		}
+
+		data "confluentacl_orphaned_acls" "example" {
+			cluster_id    = confluentacl_acl.example.cluster_id
+			rest_endpoint = confluentacl_acl.example.rest_endpoint
+		}
+		`, clusterId, restEndpoint)
+}
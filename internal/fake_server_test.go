@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"terraform-provider-confluentacl/internal/client"
+	"testing"
+	"time"
+)
+
+// fakeKafkaServer serves the service accounts and the ACLs of kafka clusters from memory, recording the ACLs created
+// and deleted through it. ACL listing ignores the query filters, which the client applies.
+type fakeKafkaServer struct {
+	*httptest.Server
+	mutex           sync.Mutex
+	acls            map[string][]client.ACLListResponse
+	serviceAccounts []client.ServiceAccount
+	created         []client.ACLListResponse
+	deleted         []client.ACLListResponse
+}
+
+func newFakeKafkaServer(t *testing.T) (*fakeKafkaServer, *client.Client) {
+	server := &fakeKafkaServer{acls: make(map[string][]client.ACLListResponse)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+	t.Cleanup(server.Close)
+
+	c := client.New("key", "secret")
+	c.SetApiUrl(server.URL + "/")
+	return server, c
+}
+
+func (s *fakeKafkaServer) addAcls(clusterId string, acls ...client.ACLListResponse) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.acls[clusterId] = append(s.acls[clusterId], acls...)
+}
+
+func (s *fakeKafkaServer) addServiceAccounts(serviceAccounts ...client.ServiceAccount) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.serviceAccounts = append(s.serviceAccounts, serviceAccounts...)
+}
+
+func (s *fakeKafkaServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch {
+	case r.URL.Path == "/access_tokens":
+		claims, _ := json.Marshal(client.JwtToken{Exp: time.Now().Add(time.Hour).Unix()})
+		writeTestJSON(w, client.AccessTokenResponse{Token: "header." + base64.RawStdEncoding.EncodeToString(claims) + ".signature"})
+	case r.URL.Path == "/service_accounts":
+		writeTestJSON(w, client.ServiceAccountResponse{Users: s.serviceAccounts})
+	case strings.HasPrefix(r.URL.Path, "/kafka/v3/clusters/") && strings.HasSuffix(r.URL.Path, "/acls"):
+		clusterId := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/kafka/v3/clusters/"), "/acls")
+		s.serveAcls(w, r, clusterId)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *fakeKafkaServer) serveAcls(w http.ResponseWriter, r *http.Request, clusterId string) {
+	switch r.Method {
+	case http.MethodGet:
+		writeTestJSON(w, client.ACLListResponseWrapper{Kind: "KafkaAclDataList", Data: s.acls[clusterId]})
+	case http.MethodPost:
+		var acl client.ACLRequest
+		if err := json.NewDecoder(r.Body).Decode(&acl); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		created := client.ACLListResponse{
+			Kind:         "KafkaAcl",
+			ResourceType: acl.ResourceType,
+			ResourceName: acl.ResourceName,
+			PatternType:  acl.PatternType,
+			Principal:    acl.Principal,
+			Host:         acl.Host,
+			Operation:    acl.Operation,
+			Permission:   acl.Permission,
+		}
+		s.acls[clusterId] = append(s.acls[clusterId], created)
+		s.created = append(s.created, created)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		params := r.URL.Query()
+		filter := &client.ACLRequest{
+			Principal:    params.Get("principal"),
+			ResourceType: params.Get("resource_type"),
+			ResourceName: params.Get("resource_name"),
+			PatternType:  params.Get("pattern_type"),
+			Host:         params.Get("host"),
+			Operation:    params.Get("operation"),
+			Permission:   params.Get("permission"),
+		}
+		var kept, deleted []client.ACLListResponse
+		for _, acl := range s.acls[clusterId] {
+			if filter.Matches(acl) {
+				deleted = append(deleted, acl)
+			} else {
+				kept = append(kept, acl)
+			}
+		}
+		s.acls[clusterId] = kept
+		s.deleted = append(s.deleted, deleted...)
+		writeTestJSON(w, client.ACLListResponseWrapper{Kind: "KafkaAclDataList", Data: deleted})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// testAclListResponse is the ACL of the rule for the principal, as listed by the fake server
+func testAclListResponse(principal string, rule AclRuleModel) client.ACLListResponse {
+	return client.ACLListResponse{
+		Kind:         "KafkaAcl",
+		ResourceType: rule.ResourceType.ValueString(),
+		ResourceName: rule.ResourceName.ValueString(),
+		PatternType:  rule.PatternType.ValueString(),
+		Principal:    principal,
+		Host:         rule.Host.ValueString(),
+		Operation:    rule.Operation.ValueString(),
+		Permission:   rule.Permission.ValueString(),
+	}
+}
+
+func writeTestJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// testAclKeys identifies the ACLs with their principal, in order
+func testAclKeys(acls []client.ACLListResponse) []string {
+	keys := make([]string, 0, len(acls))
+	for _, acl := range acls {
+		keys = append(keys, aclListResponseKey(acl))
+	}
+	sort.Strings(keys)
+	return keys
+}
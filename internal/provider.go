@@ -29,9 +29,19 @@ func NewProvider() provider.Provider {
 
 type confluentaclProvider struct{}
 
+// providerData is passed to resources and data sources when the provider is configured
+type providerData struct {
+	client                    *client.Client
+	aclPolicy                 *aclPolicy
+	sweepExpiredTemporaryAcls bool
+}
+
 type confluentaclProviderModel struct {
-	ConfluentCloudApiKey    types.String `tfsdk:"confluent_cloud_api_key"`
-	ConfluentCloudApiSecret types.String `tfsdk:"confluent_cloud_api_secret"`
+	ConfluentCloudApiKey    types.String    `tfsdk:"confluent_cloud_api_key"`
+	ConfluentCloudApiSecret types.String    `tfsdk:"confluent_cloud_api_secret"`
+	AuditLogPath            types.String    `tfsdk:"audit_log_path"`
+	SweepExpiredTempAcls    types.Bool      `tfsdk:"sweep_expired_temporary_acls"`
+	AclPolicy               *AclPolicyModel `tfsdk:"acl_policy"`
 }
 
 // Metadata returns the provider type name.
@@ -50,6 +60,15 @@ func (p *confluentaclProvider) Schema(_ context.Context, _ provider.SchemaReques
 				Optional:  true,
 				Sensitive: true,
 			},
+			"audit_log_path": schema.StringAttribute{
+				Optional: true,
+			},
+			"sweep_expired_temporary_acls": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"acl_policy": aclPolicyBlock(),
 		},
 	}
 }
@@ -91,14 +110,39 @@ func (p *confluentaclProvider) Configure(ctx context.Context, req provider.Confi
 	}
 
 	client_ := client.New(cloudApiKey, cloudApiSecret)
-	resp.DataSourceData = client_
-	resp.ResourceData = client_
+	if !config.AuditLogPath.IsNull() {
+		err := client_.EnableAuditLog(config.AuditLogPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_log_path"),
+				"Unable to open audit log", err.Error(),
+			)
+			return
+		}
+	}
+	policy, diags := newAclPolicy(config.AclPolicy)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data := &providerData{
+		client:                    client_,
+		aclPolicy:                 policy,
+		sweepExpiredTemporaryAcls: config.SweepExpiredTempAcls.ValueBool(),
+	}
+	resp.DataSourceData = data
+	resp.ResourceData = data
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *confluentaclProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewSchemaRegistryDataSource,
+		NewAclsDataSource,
+		NewOrphanedAclsDataSource,
+		NewAccessCheckDataSource,
+		NewAclAnalysisDataSource,
+		NewAclPolicyFileDataSource,
 	}
 }
 
@@ -107,5 +151,10 @@ func (p *confluentaclProvider) Resources(_ context.Context) []func() resource.Re
 	return []func() resource.Resource{
 		NewAclResource,
 		NewApiKeyResource,
+		NewPrincipalAclsResource,
+		NewAclSetResource,
+		NewKafkaClientAccessResource,
+		NewTemporaryAclResource,
+		NewAclMirrorResource,
 	}
 }
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"os"
+	"terraform-provider-confluentacl/internal/client"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -57,3 +58,8 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal(envVarTestRestEndpoint + " must be set for acceptance tests")
 	}
 }
+
+// testAccClient is used to change real resources outside terraform during acceptance tests
+func testAccClient() *client.Client {
+	return client.New(os.Getenv(envVarCloudApiKey), os.Getenv(envVarCloudApiSecret))
+}
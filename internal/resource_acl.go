@@ -2,12 +2,17 @@ package internal
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 	"terraform-provider-confluentacl/internal/client"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,18 +23,25 @@ import (
 )
 
 var (
-	_ resource.Resource              = &AclResource{}
-	_ resource.ResourceWithConfigure = &AclResource{}
+	_ resource.Resource                     = &AclResource{}
+	_ resource.ResourceWithConfigure        = &AclResource{}
+	_ resource.ResourceWithConfigValidators = &AclResource{}
+	_ resource.ResourceWithImportState      = &AclResource{}
+	_ resource.ResourceWithUpgradeState     = &AclResource{}
+	_ resource.ResourceWithMoveState        = &AclResource{}
+	_ resource.ResourceWithModifyPlan       = &AclResource{}
 )
 
 type AclResource struct {
-	client *client.Client
+	client    *client.Client
+	aclPolicy *aclPolicy
 }
 
 type AclResourceModel struct {
 	ID                 types.String `tfsdk:"id"`
 	RestEndpoint       types.String `tfsdk:"rest_endpoint"`
 	ServiceAccountName types.String `tfsdk:"service_account_name"`
+	Principal          types.String `tfsdk:"principal"`
 	ClusterId          types.String `tfsdk:"cluster_id"`
 	ResourceType       types.String `tfsdk:"resource_type"`
 	ResourceName       types.String `tfsdk:"resource_name"`
@@ -37,6 +49,41 @@ type AclResourceModel struct {
 	Host               types.String `tfsdk:"host"`
 	Operation          types.String `tfsdk:"operation"`
 	Permission         types.String `tfsdk:"permission"`
+	WaitForPropagation types.String `tfsdk:"wait_for_propagation"`
+	AdoptExisting      types.Bool   `tfsdk:"adopt_existing"`
+}
+
+// AclResourceModelV0 is the model of version 0 states, from before principals were resolved for service account
+// ACLs. Attributes added since without a version bump, like wait_for_propagation, are missing from it.
+type AclResourceModelV0 struct {
+	ID                 types.String `tfsdk:"id"`
+	RestEndpoint       types.String `tfsdk:"rest_endpoint"`
+	ServiceAccountName types.String `tfsdk:"service_account_name"`
+	Principal          types.String `tfsdk:"principal"`
+	ClusterId          types.String `tfsdk:"cluster_id"`
+	ResourceType       types.String `tfsdk:"resource_type"`
+	ResourceName       types.String `tfsdk:"resource_name"`
+	PatternType        types.String `tfsdk:"pattern_type"`
+	Host               types.String `tfsdk:"host"`
+	Operation          types.String `tfsdk:"operation"`
+	Permission         types.String `tfsdk:"permission"`
+}
+
+// AclResourceModelV1 is the model of version 1 states, with ids based on service account names. wait_for_propagation
+// is null in the states written before it was added.
+type AclResourceModelV1 struct {
+	ID                 types.String `tfsdk:"id"`
+	RestEndpoint       types.String `tfsdk:"rest_endpoint"`
+	ServiceAccountName types.String `tfsdk:"service_account_name"`
+	Principal          types.String `tfsdk:"principal"`
+	ClusterId          types.String `tfsdk:"cluster_id"`
+	ResourceType       types.String `tfsdk:"resource_type"`
+	ResourceName       types.String `tfsdk:"resource_name"`
+	PatternType        types.String `tfsdk:"pattern_type"`
+	Host               types.String `tfsdk:"host"`
+	Operation          types.String `tfsdk:"operation"`
+	Permission         types.String `tfsdk:"permission"`
+	WaitForPropagation types.String `tfsdk:"wait_for_propagation"`
 }
 
 func NewAclResource() resource.Resource {
@@ -51,11 +98,14 @@ func (r *AclResource) Configure(_ context.Context, req resource.ConfigureRequest
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*client.Client)
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
 }
 
 func (r *AclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -64,8 +114,37 @@ func (r *AclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 			"service_account_name": schema.StringAttribute{
-				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// ACLs imported by principal get the service account name of the configuration, ModifyPlan
+							// replaces them when it isn't the service account of the principal
+							resp.RequiresReplace = !req.StateValue.IsNull() || req.ConfigValue.IsNull()
+						},
+						"Changing the service account requires replacing the ACL", "Changing the service account requires replacing the ACL",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							// Principals resolved from service account names are replaced by service_account_name changes
+							resp.RequiresReplace = !req.ConfigValue.IsNull()
+						},
+						"Changing a configured principal requires replacing the ACL", "Changing a configured principal requires replacing the ACL",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
 			},
 			"cluster_id": schema.StringAttribute{
 				Required:      true,
@@ -82,7 +161,7 @@ func (r *AclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Required:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				Validators: []validator.String{
-					stringvalidator.OneOf("TOPIC", "GROUP", "CLUSTER", "TRANSACTIONAL_ID", "DELEGATION_TOKEN"),
+					stringvalidator.OneOf(aclResourceTypes...),
 				},
 			},
 			"resource_name": schema.StringAttribute{
@@ -93,7 +172,7 @@ func (r *AclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Required:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				Validators: []validator.String{
-					stringvalidator.OneOf("MATCH", "LITERAL", "PREFIXED"),
+					stringvalidator.OneOf(aclPatternTypes...),
 				},
 			},
 			"host": schema.StringAttribute{
@@ -104,18 +183,108 @@ func (r *AclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				Required:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
 				Validators: []validator.String{
-					stringvalidator.OneOf("READ", "WRITE", "CREATE", "DELETE", "ALTER", "DESCRIBE", "CLUSTER_ACTION", "DESCRIBE_CONFIGS", "ALTER_CONFIGS", "IDEMPOTENT_WRITE"),
+					stringvalidator.OneOf(aclOperations...),
 				},
 			},
 			"permission": schema.StringAttribute{
 				Required:      true,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
-				Validators:    []validator.String{stringvalidator.OneOf("ALLOW", "DENY")},
+				Validators:    []validator.String{stringvalidator.OneOf(aclPermissions...)},
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
 			},
 		},
 	}
 }
 
+// aclResourceSchemaV1 is the schema of ids based on service account names
+func aclResourceSchemaV1() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                   schema.StringAttribute{Computed: true},
+			"service_account_name": schema.StringAttribute{Optional: true},
+			"principal":            schema.StringAttribute{Optional: true, Computed: true},
+			"cluster_id":           schema.StringAttribute{Required: true},
+			"rest_endpoint":        schema.StringAttribute{Required: true},
+			"resource_type":        schema.StringAttribute{Required: true},
+			"resource_name":        schema.StringAttribute{Required: true},
+			"pattern_type":         schema.StringAttribute{Required: true},
+			"host":                 schema.StringAttribute{Required: true},
+			"operation":            schema.StringAttribute{Required: true},
+			"permission":           schema.StringAttribute{Required: true},
+			"wait_for_propagation": schema.StringAttribute{Optional: true},
+		},
+	}
+}
+
+func aclResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                   schema.StringAttribute{Computed: true},
+			"service_account_name": schema.StringAttribute{Optional: true},
+			"principal":            schema.StringAttribute{Optional: true},
+			"cluster_id":           schema.StringAttribute{Required: true},
+			"rest_endpoint":        schema.StringAttribute{Required: true},
+			"resource_type":        schema.StringAttribute{Required: true},
+			"resource_name":        schema.StringAttribute{Required: true},
+			"pattern_type":         schema.StringAttribute{Required: true},
+			"host":                 schema.StringAttribute{Required: true},
+			"operation":            schema.StringAttribute{Required: true},
+			"permission":           schema.StringAttribute{Required: true},
+		},
+	}
+}
+
+func (r *AclResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{aclValidator{}}
+}
+
+// ModifyPlan warns about high-risk changes, checks the service account and cluster exist, and enforces the acl_policy
+// of the provider
+func (r *AclResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnHighRiskRootAclChange(ctx, req, &resp.Diagnostics)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	checkPlannedAclTarget(ctx, r.client, req.Plan, &resp.Diagnostics)
+	r.requireReplaceForOtherServiceAccount(ctx, req, resp)
+	owner, principal := planAclOwner(ctx, req.Plan, &resp.Diagnostics)
+	rule := rootAclRule(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, problem := range r.aclPolicy.violations(owner, principal, rule) {
+		resp.Diagnostics.AddAttributeError(path.Root(problem.attribute), "ACL policy violation", problem.detail)
+	}
+}
+
+// requireReplaceForOtherServiceAccount replaces ACLs imported by principal when the configured service account name
+// doesn't resolve to that principal. Otherwise the service account name is only added to the state.
+func (r *AclResource) requireReplaceForOtherServiceAccount(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var stateServiceAccountName, planServiceAccountName, statePrincipal types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("service_account_name"), &stateServiceAccountName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("principal"), &statePrincipal)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_account_name"), &planServiceAccountName)...)
+	if resp.Diagnostics.HasError() || !stateServiceAccountName.IsNull() || planServiceAccountName.IsNull() {
+		return
+	}
+	if r.client != nil && !planServiceAccountName.IsUnknown() {
+		principal, err := resolveServiceAccountPrincipal(r.client, planServiceAccountName.ValueString())
+		if err == nil {
+			aliases, err := r.client.PrincipalAliases(principal)
+			if err == nil && slices.Contains(aliases, statePrincipal.ValueString()) {
+				return
+			}
+		}
+	}
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("service_account_name"))
+}
+
 func (r *AclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	// Get plan
 	var plan AclResourceModel
@@ -125,36 +294,59 @@ func (r *AclResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	userId, err := r.client.GetSaNumericId(plan.ServiceAccountName.ValueString())
+	principal, err := r.resolvePrincipal(&plan)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	tflog.Info(ctx, fmt.Sprintf("UserId %d", userId))
-	if userId == 0 {
-		resp.Diagnostics.AddError("Could not find service account with name "+plan.ServiceAccountName.ValueString(), "")
+	tflog.Info(ctx, "Principal "+principal)
+	plan.Principal = types.StringValue(principal)
+	requestBody := aclRequestFromModel(&plan, principal)
+	plan.ID = types.StringValue(makeIdForAclModel(&plan))
+
+	// Creating an existing ACL succeeds, which would leave two owners deleting it independently
+	aclsFound, _, err := findModelAcls(r.client, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
 	}
-	requestBody := &client.ACLRequest{
-		Principal:    fmt.Sprintf("User:%d", userId),
-		ResourceName: plan.ResourceName.ValueString(),
-		ResourceType: plan.ResourceType.ValueString(),
-		PatternType:  plan.PatternType.ValueString(),
-		Host:         plan.Host.ValueString(),
-		Operation:    plan.Operation.ValueString(),
-		Permission:   plan.Permission.ValueString(),
+	if len(aclsFound) > 0 && !plan.AdoptExisting.ValueBool() {
+		resp.Diagnostics.AddError(
+			"ACL already exists",
+			fmt.Sprintf("ACL %s already exists in cluster %s, it may be managed by another terraform workspace that would delete it regardless of this resource. "+
+				"Import it, or set adopt_existing = true to take ownership of it.", plan.ID.ValueString(), plan.ClusterId.ValueString()),
+		)
+		return
 	}
-	err = r.client.CreateACL(plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), requestBody)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to create ACL", err.Error())
+	if len(aclsFound) > 0 {
+		tflog.Info(ctx, "Adopting existing ACL "+plan.ID.ValueString())
+	} else {
+		ctx = client.WithAuditSource(ctx, "confluentacl_acl", plan.ID.ValueString())
+		err = r.client.CreateACL(ctx, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), requestBody)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to create ACL", err.Error())
+			return
+		}
 	}
-	plan.ID = types.StringValue(makeIdForAclModel(&plan))
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	rule := aclRuleFromAcl(client.ACLListResponse{
+		ResourceType: requestBody.ResourceType,
+		ResourceName: requestBody.ResourceName,
+		PatternType:  requestBody.PatternType,
+		Host:         requestBody.Host,
+		Operation:    requestBody.Operation,
+		Permission:   requestBody.Permission,
+	})
+	err = waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), principal, []AclRuleModel{rule}, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACL created but not propagated", err.Error())
+	}
 }
 
 func (r *AclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -165,46 +357,44 @@ func (r *AclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	userId, err := r.client.GetSaNumericId(state.ServiceAccountName.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+	principal, err := r.resolvePrincipal(&state)
+	if errors.Is(err, errServiceAccountNotFound) {
+		removeResourceOfDeletedServiceAccount(ctx, resp, "ACL "+state.ID.ValueString(), state.ServiceAccountName.ValueString())
+		return
 	}
-	if resp.Diagnostics.HasError() {
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
 		return
 	}
-	queryParams := &client.ACLRequest{
-		Principal:    fmt.Sprintf("User:%d", userId),
-		ResourceName: state.ResourceName.ValueString(),
-		ResourceType: state.ResourceType.ValueString(),
-		PatternType:  state.PatternType.ValueString(),
-		Host:         state.Host.ValueString(),
-		Operation:    state.Operation.ValueString(),
-		Permission:   state.Permission.ValueString(),
-	}
-	aclsFound, err := r.client.ListSpecificACLs(
-		state.RestEndpoint.ValueString(),
-		state.ClusterId.ValueString(),
-		queryParams,
-	)
+	state.Principal = types.StringValue(principal)
+	aclsFound, _, err := findModelAcls(r.client, &state)
 	if err != nil {
 		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
 	}
 	if len(aclsFound) > 1 {
-		resp.Diagnostics.AddError("Expected to find 1 ACL matching spec", "")
-		return
-	}
-	if len(aclsFound) == 1 {
-		acl := aclsFound[0]
-		state.ClusterId = types.StringValue(acl.ClusterId)
-		state.ResourceName = types.StringValue(acl.ResourceName)
-		state.ResourceType = types.StringValue(acl.ResourceType)
-		state.PatternType = types.StringValue(acl.PatternType)
-		state.Host = types.StringValue(acl.Host)
-		state.Operation = types.StringValue(acl.Operation)
-		state.Permission = types.StringValue(acl.Permission)
-	} else {
-		state.ID = types.StringNull()
+		resp.Diagnostics.AddError("Expected to find 1 ACL matching spec", fmt.Sprintf("Found %d ACLs matching %s", len(aclsFound), state.ID.ValueString()))
+		return
 	}
+	if len(aclsFound) == 0 {
+		resp.Diagnostics.AddWarning(
+			"ACL deleted outside terraform",
+			fmt.Sprintf("ACL %s of principal %s no longer exists in cluster %s. The resource is removed from the state and will be created again if still configured.",
+				state.ID.ValueString(), principal, state.ClusterId.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	acl := aclsFound[0]
+	state.ClusterId = types.StringValue(acl.ClusterId)
+	state.ResourceName = types.StringValue(acl.ResourceName)
+	state.ResourceType = types.StringValue(acl.ResourceType)
+	state.PatternType = types.StringValue(acl.PatternType)
+	state.Host = types.StringValue(acl.Host)
+	state.Operation = types.StringValue(acl.Operation)
+	state.Permission = types.StringValue(acl.Permission)
+	state.ID = types.StringValue(makeIdForAclModel(&state))
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -213,7 +403,16 @@ func (r *AclResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *AclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// There's no update.
+	// Every ACL attribute requires replacement, only wait_for_propagation and the service account name of ACLs imported
+	// by principal are updated
+	var plan AclResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 func (r *AclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -224,39 +423,340 @@ func (r *AclResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	userId, err := r.client.GetSaNumericId(state.ServiceAccountName.ValueString())
+	principal, err := r.resolvePrincipal(&state)
+	if errors.Is(err, errServiceAccountNotFound) {
+		// Deleting a service account deletes its ACLs
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
 	}
-	if resp.Diagnostics.HasError() {
+	state.Principal = types.StringValue(principal)
+	aclsFound, listedPrincipal, err := findModelAcls(r.client, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
 		return
 	}
-	queryParams := &client.ACLRequest{
-		Principal:    fmt.Sprintf("User:%d", userId),
-		ResourceName: state.ResourceName.ValueString(),
-		ResourceType: state.ResourceType.ValueString(),
-		PatternType:  state.PatternType.ValueString(),
-		Host:         state.Host.ValueString(),
-		Operation:    state.Operation.ValueString(),
-		Permission:   state.Permission.ValueString(),
+	if len(aclsFound) == 0 {
+		// Already gone
+		return
 	}
-	r.client.DeleteAcl(state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), queryParams)
+	queryParams := aclRequestFromModel(&state, listedPrincipal)
+	ctx = client.WithAuditSource(ctx, "confluentacl_acl", state.ID.ValueString())
+	err = r.client.DeleteAcl(ctx, state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), queryParams)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete ACL", err.Error())
 	}
+}
+
+const (
+	confluentProviderAddress = "registry.terraform.io/confluentinc/confluent"
+	confluentKafkaAclType    = "confluent_kafka_acl"
+)
+
+// confluentKafkaAclState is the state of confluent_kafka_acl resources of the official confluent provider
+type confluentKafkaAclState struct {
+	KafkaCluster []struct {
+		Id string `json:"id"`
+	} `json:"kafka_cluster"`
+	RestEndpoint string `json:"rest_endpoint"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	PatternType  string `json:"pattern_type"`
+	Principal    string `json:"principal"`
+	Host         string `json:"host"`
+	Operation    string `json:"operation"`
+	Permission   string `json:"permission"`
+}
+
+// MoveState moves confluent_kafka_acl resources of the official provider with moved blocks. Their credentials are
+// dropped, this provider authenticates with the cloud api key.
+func (r *AclResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: r.moveStateFromConfluentKafkaAcl},
+	}
+}
+
+func (r *AclResource) moveStateFromConfluentKafkaAcl(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if !strings.EqualFold(req.SourceProviderAddress, confluentProviderAddress) || req.SourceTypeName != confluentKafkaAclType {
+		return
+	}
+	if req.SourceRawState == nil {
+		resp.Diagnostics.AddError("Unable to move "+confluentKafkaAclType, "Source state is missing")
+		return
+	}
+
+	var source confluentKafkaAclState
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Unable to move "+confluentKafkaAclType, "Failed to parse source state: "+err.Error())
+		return
+	}
+	if len(source.KafkaCluster) != 1 || source.KafkaCluster[0].Id == "" {
+		resp.Diagnostics.AddError("Unable to move "+confluentKafkaAclType, "Source state has no kafka_cluster id")
+		return
+	}
+	if source.RestEndpoint == "" {
+		resp.Diagnostics.AddError(
+			"Unable to move "+confluentKafkaAclType,
+			"Source state has no rest_endpoint, it was configured in the confluent provider. Set rest_endpoint in the confluent_kafka_acl resource and apply before moving it.",
+		)
+		return
+	}
+
+	state := AclResourceModel{
+		RestEndpoint:       types.StringValue(source.RestEndpoint),
+		ServiceAccountName: types.StringNull(),
+		Principal:          types.StringValue(source.Principal),
+		ClusterId:          types.StringValue(source.KafkaCluster[0].Id),
+		ResourceType:       types.StringValue(source.ResourceType),
+		ResourceName:       types.StringValue(source.ResourceName),
+		PatternType:        types.StringValue(source.PatternType),
+		Host:               types.StringValue(source.Host),
+		Operation:          types.StringValue(source.Operation),
+		Permission:         types.StringValue(source.Permission),
+		WaitForPropagation: types.StringNull(),
+	}
+	state.ID = types.StringValue(makeIdForAclModel(&state))
+	diags := resp.TargetState.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// UpgradeState moves states of every prior version straight to the current one
+func (r *AclResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   aclResourceSchemaV0(),
+			StateUpgrader: r.upgradeAclStateFromV0,
+		},
+		1: {
+			PriorSchema:   aclResourceSchemaV1(),
+			StateUpgrader: r.upgradeAclStateFromV1,
+		},
+	}
+}
+
+// upgradeAclStateFromV0 fills the resource id principal of ACLs owned by service accounts. The ACLs themselves are
+// left untouched, as kafka lists them under either principal form.
+func (r *AclResource) upgradeAclStateFromV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var stateV0 AclResourceModelV0
+	diags := req.State.Get(ctx, &stateV0)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := AclResourceModel{
+		ID:                 stateV0.ID,
+		RestEndpoint:       stateV0.RestEndpoint,
+		ServiceAccountName: stateV0.ServiceAccountName,
+		Principal:          stateV0.Principal,
+		ClusterId:          stateV0.ClusterId,
+		ResourceType:       stateV0.ResourceType,
+		ResourceName:       stateV0.ResourceName,
+		PatternType:        stateV0.PatternType,
+		Host:               stateV0.Host,
+		Operation:          stateV0.Operation,
+		Permission:         stateV0.Permission,
+		WaitForPropagation: types.StringNull(),
+		AdoptExisting:      types.BoolNull(),
+	}
+
+	r.upgradeAclState(ctx, &state, resp)
+}
+
+// upgradeAclStateFromV1 moves ids based on service account names to ids based on principals
+func (r *AclResource) upgradeAclStateFromV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var stateV1 AclResourceModelV1
+	diags := req.State.Get(ctx, &stateV1)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := AclResourceModel{
+		ID:                 stateV1.ID,
+		RestEndpoint:       stateV1.RestEndpoint,
+		ServiceAccountName: stateV1.ServiceAccountName,
+		Principal:          stateV1.Principal,
+		ClusterId:          stateV1.ClusterId,
+		ResourceType:       stateV1.ResourceType,
+		ResourceName:       stateV1.ResourceName,
+		PatternType:        stateV1.PatternType,
+		Host:               stateV1.Host,
+		Operation:          stateV1.Operation,
+		Permission:         stateV1.Permission,
+		WaitForPropagation: stateV1.WaitForPropagation,
+		AdoptExisting:      types.BoolNull(),
+	}
+
+	r.upgradeAclState(ctx, &state, resp)
+}
+
+// upgradeAclState fills the principal and computes the id of the current version
+func (r *AclResource) upgradeAclState(ctx context.Context, state *AclResourceModel, resp *resource.UpgradeStateResponse) {
+	// Without a configured provider the principal is left for Read to resolve, along with the id
+	if r.client != nil && state.Principal.IsNull() && !state.ServiceAccountName.IsNull() {
+		principal, err := r.resolvePrincipal(state)
+		if err != nil && !errors.Is(err, errServiceAccountNotFound) {
+			resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+			return
+		}
+		if err == nil {
+			state.Principal = types.StringValue(principal)
+		}
+	}
+	state.ID = types.StringValue(makeIdForAclModel(state))
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ImportState accepts the resource id prefixed by the cluster rest endpoint, as the endpoint isn't part of the id:
+// https://pkc-00000.region.provider.confluent.cloud:443/lkc-123abc/User:sa-123abc/TOPIC#test#PREFIXED#*#READ#ALLOW
+// A service account name in place of the principal is also accepted. ACLs imported by principal keep a null
+// service_account_name, which configurations naming the service account of the principal fill without replacement.
+func (r *AclResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	model, err := parseAclImportId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
 
+	principal, err := r.resolvePrincipal(model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	model.Principal = types.StringValue(principal)
+	model.ID = types.StringValue(makeIdForAclModel(model))
+	aclsFound, _, err := findModelAcls(r.client, model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	if len(aclsFound) == 0 {
+		resp.Diagnostics.AddError("Cannot import non-existent ACL", "No ACL matching "+model.ID.ValueString()+" was found in the cluster")
+		return
+	}
+	diags := resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
 }
 
+func parseAclImportId(importId string) (*AclResourceModel, error) {
+	invalidEndpointErr := errors.New("import id must start with the cluster rest endpoint, e.g. https://pkc-00000.region.provider.confluent.cloud:443/<id>")
+	schemeEnd := strings.Index(importId, "://")
+	if schemeEnd <= 0 {
+		return nil, invalidEndpointErr
+	}
+	endpointEnd := strings.Index(importId[schemeEnd+3:], "/")
+	if endpointEnd <= 0 {
+		return nil, invalidEndpointErr
+	}
+	restEndpoint := importId[:schemeEnd+3+endpointEnd]
+	id := importId[len(restEndpoint)+1:]
+	idParts := strings.SplitN(id, "/", 3)
+	if len(idParts) != 3 {
+		return nil, errors.New("expected id with format <cluster_id>/<service_account_name>/<acl>, got: " + id)
+	}
+	aclParts := strings.Split(idParts[2], "#")
+	if len(aclParts) < 6 {
+		return nil, errors.New("expected acl with format <resource_type>#<resource_name>#<pattern_type>#<host>#<operation>#<permission>, got: " + idParts[2])
+	}
+	last := len(aclParts) - 1
+	// Parts are escaped in ids, but resource names with unescaped # are still accepted
+	unescape := func(parts ...string) types.String {
+		value, err := url.PathUnescape(strings.Join(parts, "#"))
+		if err != nil {
+			value = strings.Join(parts, "#")
+		}
+		return types.StringValue(value)
+	}
+	model := &AclResourceModel{
+		RestEndpoint:       types.StringValue(restEndpoint),
+		ClusterId:          unescape(idParts[0]),
+		ServiceAccountName: types.StringNull(),
+		Principal:          types.StringNull(),
+		ResourceType:       unescape(aclParts[0]),
+		ResourceName:       unescape(aclParts[1 : last-3]...),
+		PatternType:        unescape(aclParts[last-3]),
+		Host:               unescape(aclParts[last-2]),
+		Operation:          unescape(aclParts[last-1]),
+		Permission:         unescape(aclParts[last]),
+	}
+	owner := unescape(idParts[1])
+	if principalRegex.MatchString(owner.ValueString()) {
+		model.Principal = owner
+	} else {
+		model.ServiceAccountName = owner
+	}
+	model.ID = types.StringValue(makeIdForAclModel(model))
+	return model, nil
+}
+
+// resolvePrincipal returns the kafka principal of the ACL, either given directly or from the service account name.
+// Service accounts are resolved to their resource id principal (User:sa-123abc).
+func (r *AclResource) resolvePrincipal(model *AclResourceModel) (string, error) {
+	if model.ServiceAccountName.IsNull() {
+		return model.Principal.ValueString(), nil
+	}
+	return resolveServiceAccountPrincipal(r.client, model.ServiceAccountName.ValueString())
+}
+
+// findModelAcls lists the ACLs matching the model under every form of its principal. ACLs created with legacy numeric
+// principals (User:12345) are still found for models resolving to resource id principals (User:sa-123abc).
+// Returns the principal form the ACLs are listed with.
+func findModelAcls(c *client.Client, model *AclResourceModel) ([]client.ACLListResponse, string, error) {
+	principals, err := c.PrincipalAliases(model.Principal.ValueString())
+	if err != nil {
+		return nil, "", err
+	}
+	for _, principal := range principals {
+		aclsFound, err := c.ListSpecificACLs(
+			model.RestEndpoint.ValueString(),
+			model.ClusterId.ValueString(),
+			aclRequestFromModel(model, principal),
+		)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(aclsFound) > 0 {
+			return aclsFound, principal, nil
+		}
+	}
+	return nil, "", nil
+}
+
+func aclRequestFromModel(model *AclResourceModel, principal string) *client.ACLRequest {
+	return &client.ACLRequest{
+		Principal:    principal,
+		ResourceName: model.ResourceName.ValueString(),
+		ResourceType: model.ResourceType.ValueString(),
+		PatternType:  model.PatternType.ValueString(),
+		Host:         model.Host.ValueString(),
+		Operation:    model.Operation.ValueString(),
+		Permission:   model.Permission.ValueString(),
+	}
+}
+
+// aclIdEscaper escapes the separators of id parts, so names containing / or # can't be confused with other ACLs
+var aclIdEscaper = strings.NewReplacer("%", "%25", "/", "%2F", "#", "%23")
+
+// makeIdForAclModel identifies the ACL by cluster, principal and ACL tuple. The principal of service accounts is their
+// immutable resource id (User:sa-123abc), so renaming them keeps the id. Until the principal of a service account is
+// resolved, the service account name is used.
 func makeIdForAclModel(model *AclResourceModel) string {
-	return fmt.Sprintf("%s/%s/%s",
-		model.ClusterId.ValueString(),
-		model.ServiceAccountName.ValueString(),
-		strings.Join([]string{
-			model.ResourceType.ValueString(),
-			model.ResourceName.ValueString(),
-			model.PatternType.ValueString(),
-			model.Host.ValueString(),
-			model.Operation.ValueString(),
-			model.Permission.ValueString(),
-		}, "#"))
+	owner := model.Principal.ValueString()
+	if model.Principal.IsNull() || model.Principal.IsUnknown() {
+		owner = model.ServiceAccountName.ValueString()
+	}
+	aclParts := []string{
+		model.ResourceType.ValueString(),
+		model.ResourceName.ValueString(),
+		model.PatternType.ValueString(),
+		model.Host.ValueString(),
+		model.Operation.ValueString(),
+		model.Permission.ValueString(),
+	}
+	for i, part := range aclParts {
+		aclParts[i] = aclIdEscaper.Replace(part)
+	}
+	return fmt.Sprintf("%s/%s/%s", aclIdEscaper.Replace(model.ClusterId.ValueString()), aclIdEscaper.Replace(owner), strings.Join(aclParts, "#"))
 }
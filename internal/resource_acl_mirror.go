@@ -0,0 +1,587 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource               = &AclMirrorResource{}
+	_ resource.ResourceWithConfigure  = &AclMirrorResource{}
+	_ resource.ResourceWithModifyPlan = &AclMirrorResource{}
+)
+
+// AclMirrorResource copies the ACLs of a source cluster to a destination cluster, e.g. for disaster recovery. ACLs
+// added or removed in the source, or changed in the destination, are reported as drift and synced on apply. Only the
+// ACLs the mirror created, or adopted with adopt_existing, are owned by it and deleted when no longer mirrored.
+type AclMirrorResource struct {
+	client    *client.Client
+	aclPolicy *aclPolicy
+}
+
+type AclMirrorResourceModel struct {
+	ID                      types.String          `tfsdk:"id"`
+	SourceRestEndpoint      types.String          `tfsdk:"source_rest_endpoint"`
+	SourceClusterId         types.String          `tfsdk:"source_cluster_id"`
+	DestinationRestEndpoint types.String          `tfsdk:"destination_rest_endpoint"`
+	DestinationClusterId    types.String          `tfsdk:"destination_cluster_id"`
+	Filter                  *AclMirrorFilterModel `tfsdk:"filter"`
+	PrincipalMapping        map[string]string     `tfsdk:"principal_mapping"`
+	ResourcePrefixMapping   map[string]string     `tfsdk:"resource_prefix_mapping"`
+	AdoptExisting           types.Bool            `tfsdk:"adopt_existing"`
+	WaitForPropagation      types.String          `tfsdk:"wait_for_propagation"`
+	Acls                    types.Set             `tfsdk:"acls"`
+}
+
+// AclMirrorFilterModel selects the source ACLs to mirror, with the semantics of kafka ACL filters
+type AclMirrorFilterModel struct {
+	Principal    types.String `tfsdk:"principal"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceName types.String `tfsdk:"resource_name"`
+	PatternType  types.String `tfsdk:"pattern_type"`
+	Operation    types.String `tfsdk:"operation"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+var aclMirrorAclType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"principal":     types.StringType,
+	"resource_type": types.StringType,
+	"resource_name": types.StringType,
+	"pattern_type":  types.StringType,
+	"host":          types.StringType,
+	"operation":     types.StringType,
+	"permission":    types.StringType,
+}}
+
+func NewAclMirrorResource() resource.Resource {
+	return &AclMirrorResource{}
+}
+
+func (r *AclMirrorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_mirror"
+}
+
+func (r *AclMirrorResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
+}
+
+func (r *AclMirrorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	clusterIdValidators := []validator.String{
+		stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+	}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"source_rest_endpoint": schema.StringAttribute{
+				Required: true,
+			},
+			"source_cluster_id": schema.StringAttribute{
+				Required:   true,
+				Validators: clusterIdValidators,
+			},
+			"destination_rest_endpoint": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"destination_cluster_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    clusterIdValidators,
+			},
+			"filter": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"principal": schema.StringAttribute{
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+						},
+					},
+					"resource_type": schema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{stringvalidator.OneOf(aclFilterResourceTypes...)},
+					},
+					"resource_name": schema.StringAttribute{
+						Optional: true,
+					},
+					"pattern_type": schema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{stringvalidator.OneOf(aclFilterPatternTypes...)},
+					},
+					"operation": schema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{stringvalidator.OneOf(aclFilterOperations...)},
+					},
+					"permission": schema.StringAttribute{
+						Optional:   true,
+						Validators: []validator.String{stringvalidator.OneOf(aclFilterPermissions...)},
+					},
+				},
+			},
+			"principal_mapping": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"resource_prefix_mapping": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+			"acls": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal":     schema.StringAttribute{Computed: true},
+						"resource_type": schema.StringAttribute{Computed: true},
+						"resource_name": schema.StringAttribute{Computed: true},
+						"pattern_type":  schema.StringAttribute{Computed: true},
+						"host":          schema.StringAttribute{Computed: true},
+						"operation":     schema.StringAttribute{Computed: true},
+						"permission":    schema.StringAttribute{Computed: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ModifyPlan reads the source cluster and reports the ACLs the apply creates in and deletes from the destination as
+// warnings, so drift shows up in plan output, along with high-risk changes. The acl_policy of the provider is enforced
+// on the mirrored ACLs.
+func (r *AclMirrorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() || r.client == nil {
+		return
+	}
+	var config AclMirrorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	desired, err := r.mirroredAcls(&config)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_rest_endpoint"), "Failure to read all acls in source cluster", err.Error())
+		return
+	}
+	for _, acl := range desired {
+		principal := types.StringValue(acl.Principal)
+		for _, problem := range r.aclPolicy.violations(principal, principal, aclRuleFromAcl(acl)) {
+			resp.Diagnostics.AddAttributeError(path.Root("acls"), "ACL policy violation", client.AclSummary(acl)+": "+problem.detail)
+		}
+	}
+	if req.State.Raw.IsNull() {
+		warnHighRiskMirroredAclChanges(desired, nil, &resp.Diagnostics)
+		return
+	}
+
+	var state AclMirrorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	previous := state.aclList(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	previousAcls := make([]client.ACLListResponse, 0, len(previous))
+	for _, acl := range previous {
+		previousAcls = append(previousAcls, aclDataSourceAclListResponse(acl))
+	}
+	warnHighRiskMirroredAclChanges(desired, previousAcls, &resp.Diagnostics)
+	desiredKeys := make(map[string]bool, len(desired))
+	previousKeys := make(map[string]bool, len(previous))
+	for _, acl := range previous {
+		previousKeys[aclDataSourceAclKey(acl)] = true
+	}
+	drift := false
+	for _, acl := range desired {
+		desiredKeys[aclListResponseKey(acl)] = true
+		if !previousKeys[aclListResponseKey(acl)] {
+			drift = true
+			resp.Diagnostics.AddAttributeWarning(path.Root("acls"), "ACL mirror drift", "Creates "+client.AclSummary(acl)+" in the destination cluster")
+		}
+	}
+	for _, acl := range previous {
+		if !desiredKeys[aclDataSourceAclKey(acl)] {
+			drift = true
+			resp.Diagnostics.AddAttributeWarning(path.Root("acls"), "ACL mirror drift",
+				"Deletes "+client.AclSummary(aclDataSourceAclListResponse(acl))+" from the destination cluster")
+		}
+	}
+	// The ACLs are read from the source cluster again on apply, which may have changed since the plan
+	if drift {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("acls"), types.SetUnknown(aclMirrorAclType))...)
+	}
+}
+
+// warnHighRiskMirroredAclChanges reports the high-risk grants and DENY removals of the mirrored ACLs, per principal
+// as they're owned by different principals
+func warnHighRiskMirroredAclChanges(desired, previous []client.ACLListResponse, diags *diag.Diagnostics) {
+	var principals []string
+	planned := make(map[string][]AclRuleModel)
+	stored := make(map[string][]AclRuleModel)
+	for _, acl := range desired {
+		if _, ok := planned[acl.Principal]; !ok {
+			principals = append(principals, acl.Principal)
+		}
+		planned[acl.Principal] = append(planned[acl.Principal], aclRuleFromAcl(acl))
+	}
+	for _, acl := range previous {
+		if _, ok := planned[acl.Principal]; !ok {
+			if _, ok := stored[acl.Principal]; !ok {
+				principals = append(principals, acl.Principal)
+			}
+		}
+		stored[acl.Principal] = append(stored[acl.Principal], aclRuleFromAcl(acl))
+	}
+	for _, principal := range principals {
+		owner := aclOwner{principal: principal, description: "principal " + principal}
+		warnHighRiskAclChanges(path.Root("acls"), owner, planned[principal], owner, stored[principal], diags)
+	}
+}
+
+func (r *AclMirrorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AclMirrorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.SourceClusterId.ValueString(), plan.DestinationClusterId.ValueString()))
+	created := r.syncAcls(ctx, &plan, nil, &resp.Diagnostics)
+	if plan.Acls.IsUnknown() {
+		return
+	}
+	// ACLs created before a failure are stored all the same, so they're deleted with the tainted resource
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.waitForPropagation(ctx, &plan, created, &resp.Diagnostics)
+}
+
+func (r *AclMirrorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AclMirrorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	previous := state.aclList(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinationAcls, err := r.client.ListACLs(state.DestinationRestEndpoint.ValueString(), state.DestinationClusterId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in destination cluster", err.Error())
+		return
+	}
+	// The mirrored ACLs are the ones the mirror owns that are still in the destination, so ACLs deleted from the
+	// destination show up as drift. Changes of the source are reported by the plan.
+	mirroredKeys := make(map[string]bool, len(previous))
+	for _, acl := range previous {
+		mirroredKeys[aclDataSourceAclKey(acl)] = true
+	}
+	var mirrored []client.ACLListResponse
+	for _, acl := range destinationAcls {
+		if mirroredKeys[aclListResponseKey(acl)] {
+			mirrored = append(mirrored, acl)
+		}
+	}
+	sortAcls(mirrored)
+	state.setAcls(ctx, mirrored, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *AclMirrorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AclMirrorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	previous := state.aclList(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Acls = types.SetUnknown(aclMirrorAclType)
+	created := r.syncAcls(ctx, &plan, previous, &resp.Diagnostics)
+	if plan.Acls.IsUnknown() {
+		return
+	}
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.waitForPropagation(ctx, &plan, created, &resp.Diagnostics)
+}
+
+func (r *AclMirrorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AclMirrorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	previous := state.aclList(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.deleteAcls(ctx, &state, previous, &resp.Diagnostics)
+}
+
+// mirroredAcls lists the source ACLs selected by the filter, with their principal and resource prefix remapped for
+// the destination
+func (r *AclMirrorResource) mirroredAcls(model *AclMirrorResourceModel) ([]client.ACLListResponse, error) {
+	var filter *client.ACLRequest
+	if model.Filter != nil {
+		filter = &client.ACLRequest{
+			Principal:    model.Filter.Principal.ValueString(),
+			ResourceType: model.Filter.ResourceType.ValueString(),
+			ResourceName: model.Filter.ResourceName.ValueString(),
+			PatternType:  model.Filter.PatternType.ValueString(),
+			Operation:    model.Filter.Operation.ValueString(),
+			Permission:   model.Filter.Permission.ValueString(),
+		}
+	}
+	sourceAcls, err := r.client.ListSpecificACLs(model.SourceRestEndpoint.ValueString(), model.SourceClusterId.ValueString(), filter)
+	if err != nil {
+		return nil, err
+	}
+	mirrored := make([]client.ACLListResponse, 0, len(sourceAcls))
+	seen := make(map[string]bool, len(sourceAcls))
+	for _, acl := range sourceAcls {
+		if principal, ok := model.PrincipalMapping[acl.Principal]; ok {
+			acl.Principal = principal
+		}
+		acl.ResourceName = remapResourcePrefix(acl.ResourceType, acl.ResourceName, model.ResourcePrefixMapping)
+		acl.ClusterId = model.DestinationClusterId.ValueString()
+		if !seen[aclListResponseKey(acl)] {
+			seen[aclListResponseKey(acl)] = true
+			mirrored = append(mirrored, acl)
+		}
+	}
+	sortAcls(mirrored)
+	return mirrored, nil
+}
+
+// remapResourcePrefix replaces the longest mapped prefix of the resource name. The cluster resource and the wildcard
+// resource keep their name.
+func remapResourcePrefix(resourceType, resourceName string, prefixMapping map[string]string) string {
+	if resourceType == "CLUSTER" || resourceName == client.AclWildcardResource {
+		return resourceName
+	}
+	longest := ""
+	for prefix := range prefixMapping {
+		if strings.HasPrefix(resourceName, prefix) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	if longest == "" {
+		return resourceName
+	}
+	return prefixMapping[longest] + strings.TrimPrefix(resourceName, longest)
+}
+
+// syncAcls mirrors the source ACLs into the destination: the ones missing from the destination are created, and the
+// previously mirrored ones that aren't in the source anymore are deleted. Source ACLs the destination already holds
+// without the mirror owning them fail the sync, unless adopt_existing is set, so ACLs managed elsewhere aren't deleted
+// by the mirror later. Other ACLs of the destination are left untouched. Once ACLs are created, the model is updated
+// with the mirrored ACLs, including when a creation fails. Returns the created ACLs.
+func (r *AclMirrorResource) syncAcls(ctx context.Context, model *AclMirrorResourceModel, previous []AclDataSourceAcl, diagnostics *diag.Diagnostics) []client.ACLListResponse {
+	desired, err := r.mirroredAcls(model)
+	if err != nil {
+		diagnostics.AddError("Failure to read all acls in source cluster", err.Error())
+		return nil
+	}
+	for _, acl := range desired {
+		principal := types.StringValue(acl.Principal)
+		for _, problem := range r.aclPolicy.violations(principal, principal, aclRuleFromAcl(acl)) {
+			diagnostics.AddAttributeError(path.Root("acls"), "ACL policy violation", client.AclSummary(acl)+": "+problem.detail)
+		}
+	}
+	if diagnostics.HasError() {
+		return nil
+	}
+	restEndpoint, clusterId := model.DestinationRestEndpoint.ValueString(), model.DestinationClusterId.ValueString()
+	destinationAcls, err := r.client.ListACLs(restEndpoint, clusterId)
+	if err != nil {
+		diagnostics.AddError("Failure to read all acls in destination cluster", err.Error())
+		return nil
+	}
+	existingKeys := make(map[string]bool, len(destinationAcls))
+	for _, acl := range destinationAcls {
+		existingKeys[aclListResponseKey(acl)] = true
+	}
+	previousKeys := make(map[string]bool, len(previous))
+	for _, acl := range previous {
+		previousKeys[aclDataSourceAclKey(acl)] = true
+	}
+	desiredKeys := make(map[string]bool, len(desired))
+	for _, acl := range desired {
+		key := aclListResponseKey(acl)
+		desiredKeys[key] = true
+		if existingKeys[key] && !previousKeys[key] && !model.AdoptExisting.ValueBool() {
+			diagnostics.AddAttributeError(path.Root("acls"), "ACL already exists",
+				fmt.Sprintf("%s already exists in destination cluster %s, it may be managed by another terraform workspace that would delete it regardless of this mirror. "+
+					"Delete it from the destination, or set adopt_existing = true to take ownership of it.", client.AclSummary(acl), clusterId))
+		}
+	}
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	var removed []AclDataSourceAcl
+	for _, acl := range previous {
+		if !desiredKeys[aclDataSourceAclKey(acl)] {
+			removed = append(removed, acl)
+		}
+	}
+	r.deleteAcls(ctx, model, removed, diagnostics)
+	if diagnostics.HasError() {
+		return nil
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_acl_mirror", model.ID.ValueString())
+	var created []client.ACLListResponse
+	for _, acl := range desired {
+		if existingKeys[aclListResponseKey(acl)] {
+			continue
+		}
+		if err := r.client.CreateACL(ctx, restEndpoint, clusterId, aclRuleFromAcl(acl).aclRequest(acl.Principal)); err != nil {
+			diagnostics.AddError("Failed to create mirrored ACL", err.Error())
+			break
+		}
+		existingKeys[aclListResponseKey(acl)] = true
+		created = append(created, acl)
+	}
+	// The desired ACLs of the destination are owned by now: mirrored before, adopted or just created
+	var mirrored []client.ACLListResponse
+	for _, acl := range desired {
+		if existingKeys[aclListResponseKey(acl)] {
+			mirrored = append(mirrored, acl)
+		}
+	}
+	model.setAcls(ctx, mirrored, diagnostics)
+	return created
+}
+
+// waitForPropagation waits for the created ACLs to be listed by the destination cluster, one principal after the other
+func (r *AclMirrorResource) waitForPropagation(ctx context.Context, model *AclMirrorResourceModel, created []client.ACLListResponse, diagnostics *diag.Diagnostics) {
+	var principals []string
+	rules := make(map[string][]AclRuleModel)
+	for _, acl := range created {
+		if rules[acl.Principal] == nil {
+			principals = append(principals, acl.Principal)
+		}
+		rules[acl.Principal] = append(rules[acl.Principal], aclRuleFromAcl(acl))
+	}
+	restEndpoint, clusterId := model.DestinationRestEndpoint.ValueString(), model.DestinationClusterId.ValueString()
+	for _, principal := range principals {
+		err := waitForAclPropagation(ctx, r.client, restEndpoint, clusterId, principal, rules[principal], model.WaitForPropagation)
+		if err != nil {
+			diagnostics.AddError("ACLs mirrored but not propagated", err.Error())
+			return
+		}
+	}
+}
+
+// deleteAcls deletes mirrored ACLs from the destination, skipping the ones already deleted
+func (r *AclMirrorResource) deleteAcls(ctx context.Context, model *AclMirrorResourceModel, acls []AclDataSourceAcl, diagnostics *diag.Diagnostics) {
+	if len(acls) == 0 {
+		return
+	}
+	restEndpoint, clusterId := model.DestinationRestEndpoint.ValueString(), model.DestinationClusterId.ValueString()
+	destinationAcls, err := r.client.ListACLs(restEndpoint, clusterId)
+	if err != nil {
+		diagnostics.AddError("Failure to read all acls in destination cluster", err.Error())
+		return
+	}
+	existingKeys := make(map[string]bool, len(destinationAcls))
+	for _, acl := range destinationAcls {
+		existingKeys[aclListResponseKey(acl)] = true
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_acl_mirror", model.ID.ValueString())
+	for _, acl := range acls {
+		if !existingKeys[aclDataSourceAclKey(acl)] {
+			continue
+		}
+		if err := r.client.DeleteAcl(ctx, restEndpoint, clusterId, aclDataSourceAclRequest(acl)); err != nil {
+			diagnostics.AddError("Failed to delete mirrored ACL", err.Error())
+			return
+		}
+	}
+}
+
+// aclList returns the mirrored ACLs stored in the model
+func (m *AclMirrorResourceModel) aclList(ctx context.Context, diagnostics *diag.Diagnostics) []AclDataSourceAcl {
+	if m.Acls.IsNull() || m.Acls.IsUnknown() {
+		return nil
+	}
+	var acls []AclDataSourceAcl
+	diagnostics.Append(m.Acls.ElementsAs(ctx, &acls, false)...)
+	return acls
+}
+
+func (m *AclMirrorResourceModel) setAcls(ctx context.Context, acls []client.ACLListResponse, diagnostics *diag.Diagnostics) {
+	var diags diag.Diagnostics
+	m.Acls, diags = types.SetValueFrom(ctx, aclMirrorAclType, aclDataSourceAcls(acls))
+	diagnostics.Append(diags...)
+}
+
+func aclDataSourceAclRequest(acl AclDataSourceAcl) *client.ACLRequest {
+	return &client.ACLRequest{
+		Principal:    acl.Principal.ValueString(),
+		ResourceType: acl.ResourceType.ValueString(),
+		ResourceName: acl.ResourceName.ValueString(),
+		PatternType:  acl.PatternType.ValueString(),
+		Host:         acl.Host.ValueString(),
+		Operation:    acl.Operation.ValueString(),
+		Permission:   acl.Permission.ValueString(),
+	}
+}
+
+// aclDataSourceAclKey identifies an ACL with its principal
+func aclDataSourceAclKey(acl AclDataSourceAcl) string {
+	request := aclDataSourceAclRequest(acl)
+	return request.Principal + "/" + aclRequestKey(request)
+}
+
+func aclDataSourceAclListResponse(acl AclDataSourceAcl) client.ACLListResponse {
+	return client.ACLListResponse{
+		Principal:    acl.Principal.ValueString(),
+		ResourceType: acl.ResourceType.ValueString(),
+		ResourceName: acl.ResourceName.ValueString(),
+		PatternType:  acl.PatternType.ValueString(),
+		Host:         acl.Host.ValueString(),
+		Operation:    acl.Operation.ValueString(),
+		Permission:   acl.Permission.ValueString(),
+	}
+}
+
+func aclListResponseKey(acl client.ACLListResponse) string {
+	return acl.Principal + "/" + aclRequestKey(aclRuleFromAcl(acl).aclRequest(acl.Principal))
+}
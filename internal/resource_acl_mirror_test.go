@@ -0,0 +1,269 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAclMirror(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclMirrorConfig(testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl_mirror.example", "id", testRealResource.ClusterId+"/"+testRealResource.ClusterId),
+					resource.TestCheckResourceAttr("confluentacl_acl_mirror.example", "acls.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("confluentacl_acl_mirror.example", "acls.*", map[string]string{
+						"principal":     "User:*",
+						"resource_name": "terraform-provider-confluentacl-mirror-destination",
+						"operation":     "READ",
+					}),
+				),
+			},
+			{
+				// The mirrored ACL isn't reported as drift
+				Config:   testAccAclMirrorConfig(testRealResource.ClusterId, testRealResource.RestEndpoint),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAclMirrorExistingAcl(t *testing.T) {
+	existingAcl := &client.ACLRequest{
+		Principal:    "User:*",
+		ResourceType: "TOPIC",
+		ResourceName: "terraform-provider-confluentacl-mirror-destination",
+		PatternType:  "LITERAL",
+		Host:         "*",
+		Operation:    "READ",
+		Permission:   "ALLOW",
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			err := testAccClient().CreateACL(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, existingAcl)
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Destroying the mirror leaves the ACL it didn't create
+		CheckDestroy: func(_ *terraform.State) error {
+			acls, err := testAccClient().ListSpecificACLs(testRealResource.RestEndpoint, testRealResource.ClusterId, existingAcl)
+			if err != nil {
+				return err
+			}
+			if len(acls) == 0 {
+				return fmt.Errorf("the mirror deleted ACL %s it didn't create", existingAcl.ResourceName)
+			}
+			return testAccClient().DeleteAcl(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, existingAcl)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAclMirrorConfig(testRealResource.ClusterId, testRealResource.RestEndpoint),
+				ExpectError: regexp.MustCompile(`ACL already exists`),
+			},
+		},
+	})
+}
+
+// testAccAclMirrorConfig mirrors an ACL within the test cluster, renaming its topic so source and destination differ
+func testAccAclMirrorConfig(clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "source" {
+			principal     = "User:*"
+			cluster_id    = "%[1]s"
+			rest_endpoint = "%[2]s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-mirror-source"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+		}
+
+		resource "confluentacl_acl_mirror" "example" {
+			source_cluster_id         = "%[1]s"
+			source_rest_endpoint      = "%[2]s"
+			destination_cluster_id    = "%[1]s"
+			destination_rest_endpoint = "%[2]s"
+
+			filter = {
+				resource_type = "TOPIC"
+				resource_name = "terraform-provider-confluentacl-mirror-source"
+			}
+			resource_prefix_mapping = {
+				"terraform-provider-confluentacl-mirror-source" = "terraform-provider-confluentacl-mirror-destination"
+			}
+
+			depends_on = [confluentacl_acl.source]
+		}
+		`, clusterId, restEndpoint)
+}
+
+func TestRemapResourcePrefix(t *testing.T) {
+	mapping := map[string]string{
+		"primary.":        "dr.",
+		"primary.orders.": "dr.sales.",
+	}
+	tests := []struct {
+		resourceType string
+		resourceName string
+		want         string
+	}{
+		{"TOPIC", "primary.payments", "dr.payments"},
+		{"TOPIC", "primary.orders.created", "dr.sales.created"},
+		{"GROUP", "primary.orders.app", "dr.sales.app"},
+		{"TOPIC", "primary.", "dr."},
+		{"TOPIC", "other", "other"},
+		{"TOPIC", "*", "*"},
+		{"CLUSTER", "primary.cluster", "primary.cluster"},
+	}
+	for _, test := range tests {
+		if got := remapResourcePrefix(test.resourceType, test.resourceName, mapping); got != test.want {
+			t.Errorf("remapResourcePrefix(%s, %q) = %q, want %q", test.resourceType, test.resourceName, got, test.want)
+		}
+	}
+	if got := remapResourcePrefix("TOPIC", "primary.orders", nil); got != "primary.orders" {
+		t.Errorf("remapResourcePrefix without mapping = %q", got)
+	}
+}
+
+func TestWarnHighRiskMirroredAclChanges(t *testing.T) {
+	alter := client.ACLListResponse{Principal: "User:sa-1", ResourceType: "CLUSTER", ResourceName: "kafka-cluster", PatternType: "LITERAL", Host: "*", Operation: "ALTER", Permission: "ALLOW"}
+	deny := client.ACLListResponse{Principal: "User:sa-2", ResourceType: "TOPIC", ResourceName: "orders", PatternType: "LITERAL", Host: "*", Operation: "READ", Permission: "DENY"}
+	movedAlter := alter
+	movedAlter.Principal = "User:sa-2"
+	tests := []struct {
+		name     string
+		desired  []client.ACLListResponse
+		previous []client.ACLListResponse
+		want     []string
+	}{
+		{"create", []client.ACLListResponse{alter, deny}, nil, []string{
+			"High-risk ACL grant: Grants ALLOW ALTER on CLUSTER kafka-cluster (LITERAL) for principal User:sa-1",
+		}},
+		{"unchanged", []client.ACLListResponse{alter, deny}, []client.ACLListResponse{alter, deny}, nil},
+		{"removed from source", nil, []client.ACLListResponse{alter, deny}, []string{
+			"DENY ACL removal: Removes DENY READ on TOPIC orders (LITERAL) for principal User:sa-2",
+		}},
+		{"principal change", []client.ACLListResponse{movedAlter, deny}, []client.ACLListResponse{alter, deny}, []string{
+			"High-risk ACL grant: Grants ALLOW ALTER on CLUSTER kafka-cluster (LITERAL) for principal User:sa-2",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			warnHighRiskMirroredAclChanges(test.desired, test.previous, &diags)
+			var got []string
+			for _, warning := range diags.Warnings() {
+				got = append(got, warning.Summary()+": "+warning.Detail())
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("got warnings %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], test.want[i]) {
+					t.Errorf("got warning %q, want %q", got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSyncAcls(t *testing.T) {
+	read := testAclListResponse("User:sa-1", newAclRule("TOPIC", "orders", "LITERAL", "READ", "ALLOW"))
+	write := testAclListResponse("User:sa-1", newAclRule("TOPIC", "orders", "LITERAL", "WRITE", "ALLOW"))
+	group := testAclListResponse("User:sa-2", newAclRule("GROUP", "orders", "PREFIXED", "READ", "ALLOW"))
+	unrelated := testAclListResponse("User:sa-3", newAclRule("TOPIC", "audit", "LITERAL", "READ", "ALLOW"))
+	tests := []struct {
+		name         string
+		source       []client.ACLListResponse
+		destination  []client.ACLListResponse
+		previous     []client.ACLListResponse
+		adopt        bool
+		wantError    string
+		wantCreated  []client.ACLListResponse
+		wantDeleted  []client.ACLListResponse
+		wantMirrored []client.ACLListResponse
+	}{
+		{name: "create", source: []client.ACLListResponse{read, group}, destination: []client.ACLListResponse{unrelated},
+			wantCreated: []client.ACLListResponse{read, group}, wantMirrored: []client.ACLListResponse{read, group}},
+		{name: "update", source: []client.ACLListResponse{write, group},
+			destination: []client.ACLListResponse{read, group, unrelated}, previous: []client.ACLListResponse{read, group},
+			wantCreated: []client.ACLListResponse{write}, wantDeleted: []client.ACLListResponse{read},
+			wantMirrored: []client.ACLListResponse{write, group}},
+		{name: "deleted outside terraform", source: []client.ACLListResponse{read}, previous: []client.ACLListResponse{read},
+			wantCreated: []client.ACLListResponse{read}, wantMirrored: []client.ACLListResponse{read}},
+		{name: "existing", source: []client.ACLListResponse{read, group}, destination: []client.ACLListResponse{read},
+			wantError: "ACL already exists"},
+		{name: "adopt existing", source: []client.ACLListResponse{read, group}, destination: []client.ACLListResponse{read}, adopt: true,
+			wantCreated: []client.ACLListResponse{group}, wantMirrored: []client.ACLListResponse{read, group}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, c := newFakeKafkaServer(t)
+			server.addAcls("lkc-source", test.source...)
+			server.addAcls("lkc-destination", test.destination...)
+			r := &AclMirrorResource{client: c}
+			model := &AclMirrorResourceModel{
+				ID:                      types.StringValue("lkc-source/lkc-destination"),
+				SourceRestEndpoint:      types.StringValue(server.URL),
+				SourceClusterId:         types.StringValue("lkc-source"),
+				DestinationRestEndpoint: types.StringValue(server.URL),
+				DestinationClusterId:    types.StringValue("lkc-destination"),
+				AdoptExisting:           types.BoolValue(test.adopt),
+				Acls:                    types.SetUnknown(aclMirrorAclType),
+			}
+			var diags diag.Diagnostics
+			r.syncAcls(context.Background(), model, aclDataSourceAcls(test.previous), &diags)
+			if test.wantError != "" {
+				if !diags.HasError() || diags.Errors()[0].Summary() != test.wantError {
+					t.Errorf("got diagnostics %v, want error %q", diags, test.wantError)
+				}
+				if len(server.created) > 0 || len(server.deleted) > 0 {
+					t.Errorf("got created %v and deleted %v, want no change", server.created, server.deleted)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got, want := testAclKeys(server.created), testAclKeys(test.wantCreated); !slices.Equal(got, want) {
+				t.Errorf("got created %q, want %q", got, want)
+			}
+			if got, want := testAclKeys(server.deleted), testAclKeys(test.wantDeleted); !slices.Equal(got, want) {
+				t.Errorf("got deleted %q, want %q", got, want)
+			}
+			var mirrored []client.ACLListResponse
+			for _, acl := range model.aclList(context.Background(), &diags) {
+				mirrored = append(mirrored, aclDataSourceAclListResponse(acl))
+			}
+			if got, want := testAclKeys(mirrored), testAclKeys(test.wantMirrored); !slices.Equal(got, want) {
+				t.Errorf("got mirrored %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                     = &AclSetResource{}
+	_ resource.ResourceWithConfigure        = &AclSetResource{}
+	_ resource.ResourceWithConfigValidators = &AclSetResource{}
+	_ resource.ResourceWithModifyPlan       = &AclSetResource{}
+)
+
+// AclSetResource manages many ACLs of a principal in a cluster. Unlike PrincipalAclsResource, ACLs of the principal
+// that aren't rules of the set are left alone.
+type AclSetResource struct {
+	client    *client.Client
+	aclPolicy *aclPolicy
+}
+
+type AclSetResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	RestEndpoint       types.String   `tfsdk:"rest_endpoint"`
+	ClusterId          types.String   `tfsdk:"cluster_id"`
+	ServiceAccountName types.String   `tfsdk:"service_account_name"`
+	Principal          types.String   `tfsdk:"principal"`
+	Rules              []AclRuleModel `tfsdk:"rule"`
+	WaitForPropagation types.String   `tfsdk:"wait_for_propagation"`
+	AdoptExisting      types.Bool     `tfsdk:"adopt_existing"`
+}
+
+func NewAclSetResource() resource.Resource {
+	return &AclSetResource{}
+}
+
+func (r *AclSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acl_set"
+}
+
+func (r *AclSetResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
+}
+
+func (r *AclSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.SetNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: aclRuleNestedAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AclSetResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{aclRulesValidator{attribute: "rule"}}
+}
+
+// ModifyPlan warns about high-risk changes, checks the service account and cluster exist, and enforces the acl_policy
+// of the provider
+func (r *AclSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnHighRiskAclRuleChanges(ctx, req, req.Plan, "rule", &resp.Diagnostics)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	checkPlannedAclTarget(ctx, r.client, req.Plan, &resp.Diagnostics)
+	r.aclPolicy.checkPlannedAclRules(ctx, req.Plan, "rule", &resp.Diagnostics)
+}
+
+func (r *AclSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan AclSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, plan.ServiceAccountName, plan.Principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	plan.Principal = types.StringValue(principal)
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.ClusterId.ValueString(), principal))
+	r.applyRules(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err = waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Rules, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *AclSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state AclSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if errors.Is(err, errServiceAccountNotFound) {
+		removeResourceOfDeletedServiceAccount(ctx, resp, "ACL set "+state.ID.ValueString(), state.ServiceAccountName.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	state.Principal = types.StringValue(principal)
+	aclsFound, err := listPrincipalAcls(r.client, state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	// Rules deleted outside terraform are dropped from the state, so they're planned to be created again
+	state.Rules = aclRulesFromAcls(filterAclsByRules(aclsFound, state.Rules))
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *AclSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state AclSetResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyRules(ctx, &plan, state.Rules, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Rules, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *AclSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state AclSetResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previousRules := state.Rules
+	state.Rules = nil
+	r.applyRules(ctx, &state, previousRules, &resp.Diagnostics)
+}
+
+func (r *AclSetResource) applyRules(ctx context.Context, model *AclSetResourceModel, previousRules []AclRuleModel, diagnostics *diag.Diagnostics) {
+	checkExistingAclRules(r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), model.Principal.ValueString(), model.Rules, previousRules, model.AdoptExisting, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_acl_set", model.ID.ValueString())
+	err := applyAclRuleChanges(ctx, r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), model.Principal.ValueString(), model.Rules, previousRules)
+	if err != nil {
+		diagnostics.AddError("Failed to apply ACL rules of principal "+model.Principal.ValueString(), err.Error())
+	}
+}
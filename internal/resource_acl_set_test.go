@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAclSetCreation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclSetConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_acl_set.example", "id"),
+					resource.TestCheckResourceAttr("confluentacl_acl_set.example", "rule.#", "2"),
+				),
+			},
+			{
+				// Only the topic rule is replaced, the group rule is left untouched
+				Config: testAccAclSetConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "WRITE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl_set.example", "rule.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAclSetMissingServiceAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Only a warning, as the service account may be created by the same apply
+				Config:             testAccAclSetConfig(testRealResource.SaName+"-missing", testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccAclSetConfig(testRealResource.SaName+"-missing", testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+				ExpectError: regexp.MustCompile(`Failed to resolve ACL principal`),
+			},
+		},
+	})
+}
+
+func TestAclSetAdoptExisting(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			principal, err := resolveServiceAccountPrincipal(testAccClient(), testRealResource.SaName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			existingRule := newAclRule("TOPIC", "terraform-provider-confluentacl-test", "LITERAL", "READ", "ALLOW")
+			err = testAccClient().CreateACL(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, existingRule.aclRequest(principal))
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAclSetConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+				ExpectError: regexp.MustCompile(`ACL already exists`),
+			},
+			{
+				Config: strings.Replace(
+					testAccAclSetConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+					`rest_endpoint        = "`+testRealResource.RestEndpoint+`"`, `rest_endpoint        = "`+testRealResource.RestEndpoint+`"
+			adopt_existing       = true`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl_set.example", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("confluentacl_acl_set.example", "rule.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAclSetConfig(saName, clusterId, restEndpoint, topicOperation string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl_set" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			rule {
+				resource_type = "TOPIC"
+				resource_name = "terraform-provider-confluentacl-test"
+				pattern_type  = "LITERAL"
+				host          = "*"
+				operation     = "%s"
+				permission    = "ALLOW"
+			}
+
+			rule {
+				resource_type = "GROUP"
+				resource_name = "terraform-provider-confluentacl-test"
+				pattern_type  = "PREFIXED"
+				host          = "*"
+				operation     = "READ"
+				permission    = "ALLOW"
+			}
+		}
+		`, saName, clusterId, restEndpoint, topicOperation)
+}
+
+func TestApplyAclRuleChanges(t *testing.T) {
+	read := newAclRule("TOPIC", "orders", "LITERAL", "READ", "ALLOW")
+	write := newAclRule("TOPIC", "orders", "LITERAL", "WRITE", "ALLOW")
+	describe := newAclRule("TOPIC", "orders", "LITERAL", "DESCRIBE", "ALLOW")
+	group := newAclRule("GROUP", "orders", "PREFIXED", "READ", "ALLOW")
+	tests := []struct {
+		name        string
+		existing    []client.ACLListResponse
+		previous    []AclRuleModel
+		desired     []AclRuleModel
+		wantCreated []client.ACLListResponse
+		wantDeleted []client.ACLListResponse
+	}{
+		{"create", []client.ACLListResponse{testAclListResponse("User:sa-1", group)}, nil, []AclRuleModel{read, write},
+			[]client.ACLListResponse{testAclListResponse("User:sa-1", read), testAclListResponse("User:sa-1", write)}, nil},
+		{"update",
+			[]client.ACLListResponse{
+				testAclListResponse("User:sa-1", read), testAclListResponse("User:sa-1", write),
+				testAclListResponse("User:sa-1", group), testAclListResponse("User:sa-2", write),
+			},
+			[]AclRuleModel{read, write}, []AclRuleModel{read, describe},
+			[]client.ACLListResponse{testAclListResponse("User:sa-1", describe)},
+			[]client.ACLListResponse{testAclListResponse("User:sa-1", write)}},
+		{"listed by numeric id", []client.ACLListResponse{testAclListResponse("User:101", read), testAclListResponse("User:101", write)},
+			[]AclRuleModel{read, write}, []AclRuleModel{read, describe},
+			[]client.ACLListResponse{testAclListResponse("User:sa-1", describe)},
+			[]client.ACLListResponse{testAclListResponse("User:101", write)}},
+		{"deleted outside terraform", nil, []AclRuleModel{read}, []AclRuleModel{read},
+			[]client.ACLListResponse{testAclListResponse("User:sa-1", read)}, nil},
+		{"delete", []client.ACLListResponse{testAclListResponse("User:sa-1", read), testAclListResponse("User:sa-1", group)},
+			[]AclRuleModel{read}, nil, nil, []client.ACLListResponse{testAclListResponse("User:sa-1", read)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, c := newFakeKafkaServer(t)
+			server.addServiceAccounts(client.ServiceAccount{Id: "sa-1", UserId: 101, ServiceName: "app"})
+			server.addAcls("lkc-123abc", test.existing...)
+			err := applyAclRuleChanges(context.Background(), c, server.URL, "lkc-123abc", "User:sa-1", test.desired, test.previous)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := testAclKeys(server.created), testAclKeys(test.wantCreated); !slices.Equal(got, want) {
+				t.Errorf("got created %q, want %q", got, want)
+			}
+			if got, want := testAclKeys(server.deleted), testAclKeys(test.wantDeleted); !slices.Equal(got, want) {
+				t.Errorf("got deleted %q, want %q", got, want)
+			}
+		})
+	}
+}
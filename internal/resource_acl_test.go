@@ -1,11 +1,22 @@
 package internal
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
+	"terraform-provider-confluentacl/internal/client"
 	"testing"
 
 	"github.com/hashicorp/go-version"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
@@ -23,12 +34,277 @@ func TestAclCreation(t *testing.T) {
 					resource.TestCheckResourceAttrSet("confluentacl_confluentacl.example", "id"),
 					resource.TestCheckResourceAttr("confluentacl_acl.example", "cluster_id", testRealResource.ClusterId),
 					resource.TestCheckResourceAttr("confluentacl_acl.example", "service_account_name", testRealResource.SaName),
+					resource.TestMatchResourceAttr("confluentacl_acl.example", "principal", regexp.MustCompile(`^User:sa-`)),
+					resource.TestMatchResourceAttr("confluentacl_acl.example", "id", regexp.MustCompile(`^lkc-[^/]+/User:sa-[^/]+/TOPIC#test#PREFIXED#\*#READ#ALLOW$`)),
+				),
+			},
+			{
+				ResourceName:      "confluentacl_acl.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					// Imported by service account name, like the configuration
+					return testRealResource.RestEndpoint + "/" + testRealResource.ClusterId + "/" + testRealResource.SaName + "/TOPIC#test#PREFIXED#*#READ#ALLOW", nil
+				},
+			},
+			{
+				// Imported with the id of the resource, which has the principal instead of the service account name
+				ResourceName:            "confluentacl_acl.example",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"service_account_name"},
+				ImportStateIdFunc:       testAccAclImportId("confluentacl_acl.example"),
+				ImportStatePersist:      true,
+			},
+			{
+				// The service account name of the configuration is filled without replacing the imported ACL
+				Config: testAccAclConfig(testRealResource.SaName, testRealResource.EnvId, testRealResource.ClusterId, testRealResource.RestEndpoint),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("confluentacl_acl.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "service_account_name", testRealResource.SaName),
+				),
+			},
+		},
+	})
+}
+
+// testAccAclImportId returns the import id of an ACL resource: its id prefixed by the cluster rest endpoint
+func testAccAclImportId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		state, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", resourceName)
+		}
+		return state.Primary.Attributes["rest_endpoint"] + "/" + state.Primary.ID, nil
+	}
+}
+
+func TestAclCreationWithPrincipal(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclPrincipalConfig("User:*", testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_acl.example", "id"),
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "principal", "User:*"),
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "wait_for_propagation", "1m"),
+					resource.TestCheckNoResourceAttr("confluentacl_acl.example", "service_account_name"),
+				),
+			},
+		},
+	})
+}
+
+func TestAclDeletedOutsideTerraform(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAclPrincipalConfig("User:*", testRealResource.ClusterId, testRealResource.RestEndpoint),
+			},
+			{
+				// Refresh drops the deleted ACL from the state and apply creates it again
+				PreConfig: func() {
+					err := testAccClient().DeleteAcl(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, &client.ACLRequest{
+						Principal:    "User:*",
+						ResourceType: "TOPIC",
+						ResourceName: "terraform-provider-confluentacl-test",
+						PatternType:  "LITERAL",
+						Host:         "*",
+						Operation:    "READ",
+						Permission:   "DENY",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccAclPrincipalConfig("User:*", testRealResource.ClusterId, testRealResource.RestEndpoint),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_acl.example", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAclMoveFromConfluentKafkaAcl(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"confluent": {Source: "confluentinc/confluent"},
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config:                   testAccConfluentKafkaAclConfig(testRealResource.SaName, testRealResource.EnvId, testRealResource.ClusterId, testRealResource.RestEndpoint, ""),
+			},
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"confluent": {Source: "confluentinc/confluent"},
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Config: testAccConfluentKafkaAclConfig(testRealResource.SaName, testRealResource.EnvId, testRealResource.ClusterId, testRealResource.RestEndpoint, `
+					moved {
+						from = confluent_kafka_acl.example
+						to   = confluentacl_acl.example
+					}
+
+					resource "confluentacl_acl" "example" {
+						principal     = "User:*"
+						cluster_id    = confluentacl_api_key.example.resource_id
+						rest_endpoint = "`+testRealResource.RestEndpoint+`"
+
+						resource_type = "TOPIC"
+						resource_name = "terraform-provider-confluentacl-move-test"
+						pattern_type  = "LITERAL"
+						host          = "*"
+						operation     = "READ"
+						permission    = "DENY"
+					}
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("confluentacl_acl.example", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "principal", "User:*"),
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "cluster_id", testRealResource.ClusterId),
+				),
+			},
+		},
+	})
+}
+
+func TestAclPolicyViolation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "confluentacl" {
+						acl_policy {
+							forbid_wildcard_allow = true
+						}
+					}
+					` + strings.Replace(testAccAclPrincipalConfig("User:*", testRealResource.ClusterId, testRealResource.RestEndpoint), `"DENY"`, `"ALLOW"`, 1),
+				ExpectError: regexp.MustCompile(`acl_policy.forbid_wildcard_allow`),
+			},
+		},
+	})
+}
+
+func TestAclAdoptExisting(t *testing.T) {
+	existingAcl := &client.ACLRequest{
+		Principal:    "User:*",
+		ResourceType: "TOPIC",
+		ResourceName: "terraform-provider-confluentacl-adopt-test",
+		PatternType:  "LITERAL",
+		Host:         "*",
+		Operation:    "READ",
+		Permission:   "DENY",
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			err := testAccClient().CreateACL(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, existingAcl)
+			if err != nil {
+				t.Fatal(err)
+			}
+		},
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAclCombinationConfig(testRealResource.ClusterId, testRealResource.RestEndpoint, "TOPIC", existingAcl.ResourceName, "LITERAL", "READ"),
+				ExpectError: regexp.MustCompile(`ACL already exists`),
+			},
+			{
+				Config: strings.Replace(
+					testAccAclCombinationConfig(testRealResource.ClusterId, testRealResource.RestEndpoint, "TOPIC", existingAcl.ResourceName, "LITERAL", "READ"),
+					`permission    = "DENY"`, `permission    = "DENY"
+			adopt_existing = true`, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_acl.example", "adopt_existing", "true"),
 				),
 			},
 		},
 	})
 }
 
+func TestAclInvalidCombination(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAclCombinationConfig(testRealResource.ClusterId, testRealResource.RestEndpoint, "TOPIC", "test", "LITERAL", "IDEMPOTENT_WRITE"),
+				ExpectError: regexp.MustCompile(`Operation IDEMPOTENT_WRITE isn't valid for TOPIC resources`),
+			},
+			{
+				Config:      testAccAclCombinationConfig(testRealResource.ClusterId, testRealResource.RestEndpoint, "CLUSTER", "test", "LITERAL", "DESCRIBE"),
+				ExpectError: regexp.MustCompile(`The name of CLUSTER resources must be kafka-cluster`),
+			},
+			{
+				Config:      testAccAclCombinationConfig(testRealResource.ClusterId, testRealResource.RestEndpoint, "TOPIC", "test", "MATCH", "READ"),
+				ExpectError: regexp.MustCompile(`MATCH is only a filter`),
+			},
+		},
+	})
+}
+
+func TestAclMissingTargets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Only a warning, as the service account may be created by the same apply
+				Config:             testAccAclServiceAccountConfig(testRealResource.SaName+"-missing", testRealResource.ClusterId, testRealResource.RestEndpoint),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccAclServiceAccountConfig(testRealResource.SaName+"-missing", testRealResource.ClusterId, testRealResource.RestEndpoint),
+				ExpectError: regexp.MustCompile(`Failed to resolve ACL principal`),
+			},
+			{
+				Config:      testAccAclPrincipalConfig("User:*", "lkc-missing", testRealResource.RestEndpoint),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Kafka cluster not found`),
+			},
+		},
+	})
+}
+
 func testAccAclConfig(saName, envId, resourceId, restEndpoint string) string {
 	return fmt.Sprintf(`
 		resource "confluentacl_api_key" "example" {
@@ -51,3 +327,336 @@ func testAccAclConfig(saName, envId, resourceId, restEndpoint string) string {
 			}
 		`, saName, envId, resourceId, restEndpoint)
 }
+
+func testAccAclServiceAccountConfig(saName, clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-test"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+		}
+		`, saName, clusterId, restEndpoint)
+}
+
+func testAccAclPrincipalConfig(principal, clusterId, restEndpoint string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			principal     = "%s"
+			cluster_id    = "%s"
+			rest_endpoint = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-test"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "DENY"
+
+			wait_for_propagation = "1m"
+		}
+		`, principal, clusterId, restEndpoint)
+}
+
+func testAccAclCombinationConfig(clusterId, restEndpoint, resourceType, resourceName, patternType, operation string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_acl" "example" {
+			principal     = "User:*"
+			cluster_id    = "%s"
+			rest_endpoint = "%s"
+
+			resource_type = "%s"
+			resource_name = "%s"
+			pattern_type  = "%s"
+			host          = "*"
+			operation     = "%s"
+			permission    = "DENY"
+		}
+		`, clusterId, restEndpoint, resourceType, resourceName, patternType, operation)
+}
+
+// testAccConfluentKafkaAclConfig has an ACL of the official confluent provider, unless the acl configuration replaces it
+func testAccConfluentKafkaAclConfig(saName, envId, clusterId, restEndpoint, aclConfig string) string {
+	if aclConfig == "" {
+		aclConfig = fmt.Sprintf(`
+			resource "confluent_kafka_acl" "example" {
+				kafka_cluster {
+					id = confluentacl_api_key.example.resource_id
+				}
+				rest_endpoint = "%s"
+				credentials {
+					key    = confluentacl_api_key.example.api_key
+					secret = confluentacl_api_key.example.api_secret
+				}
+
+				resource_type = "TOPIC"
+				resource_name = "terraform-provider-confluentacl-move-test"
+				pattern_type  = "LITERAL"
+				principal     = "User:*"
+				host          = "*"
+				operation     = "READ"
+				permission    = "DENY"
+			}
+		`, restEndpoint)
+	}
+	return fmt.Sprintf(`
+		resource "confluentacl_api_key" "example" {
+			service_account_name = "%s"
+			environment_id       = "%s"
+			resource_id          = "%s"
+		}
+		%s
+		`, saName, envId, clusterId, aclConfig)
+}
+
+func TestParseAclImportIdErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		importId string
+		want     string
+	}{
+		{"no endpoint", "lkc-123abc/User:sa-123abc/TOPIC#orders#LITERAL#*#READ#ALLOW", "must start with the cluster rest endpoint"},
+		{"endpoint only", "https://pkc-00000.confluent.cloud:443", "must start with the cluster rest endpoint"},
+		{"missing owner", "https://pkc-00000.confluent.cloud:443/lkc-123abc/TOPIC#orders#LITERAL#*#READ#ALLOW", "expected id with format"},
+		{"short acl", "https://pkc-00000.confluent.cloud:443/lkc-123abc/User:sa-123abc/TOPIC#orders#LITERAL#READ#ALLOW", "expected acl with format"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseAclImportId(test.importId)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestAclIdRoundTrip(t *testing.T) {
+	tests := []struct {
+		name         string
+		owner        string
+		resourceName string
+		wantId       string
+	}{
+		{"plain", "User:sa-123abc", "orders", "lkc-123abc/User:sa-123abc/TOPIC#orders#LITERAL#*#READ#ALLOW"},
+		{"slash", "User:sa-123abc", "team/orders", "lkc-123abc/User:sa-123abc/TOPIC#team%2Forders#LITERAL#*#READ#ALLOW"},
+		{"hash", "User:sa-123abc", "orders#v1", "lkc-123abc/User:sa-123abc/TOPIC#orders%23v1#LITERAL#*#READ#ALLOW"},
+		{"percent", "User:sa-123abc", "orders%2Fv1", "lkc-123abc/User:sa-123abc/TOPIC#orders%252Fv1#LITERAL#*#READ#ALLOW"},
+		{"service account name", "team/app", "orders", "lkc-123abc/team%2Fapp/TOPIC#orders#LITERAL#*#READ#ALLOW"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := &AclResourceModel{
+				ClusterId:          types.StringValue("lkc-123abc"),
+				ServiceAccountName: types.StringNull(),
+				Principal:          types.StringNull(),
+				ResourceType:       types.StringValue("TOPIC"),
+				ResourceName:       types.StringValue(test.resourceName),
+				PatternType:        types.StringValue("LITERAL"),
+				Host:               types.StringValue("*"),
+				Operation:          types.StringValue("READ"),
+				Permission:         types.StringValue("ALLOW"),
+			}
+			if principalRegex.MatchString(test.owner) {
+				model.Principal = types.StringValue(test.owner)
+			} else {
+				model.ServiceAccountName = types.StringValue(test.owner)
+			}
+			id := makeIdForAclModel(model)
+			if id != test.wantId {
+				t.Fatalf("got id %q, want %q", id, test.wantId)
+			}
+			parsed, err := parseAclImportId("https://pkc-00000.confluent.cloud:443/" + id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.ID.ValueString() != id || parsed.ResourceName != model.ResourceName ||
+				parsed.Principal != model.Principal || parsed.ServiceAccountName != model.ServiceAccountName {
+				t.Errorf("got %+v after round trip, want %+v", parsed, model)
+			}
+		})
+	}
+}
+
+func TestAclStateUpgrade(t *testing.T) {
+	r := &AclResource{}
+	upgraders := r.UpgradeState(context.Background())
+	tests := []struct {
+		name    string
+		version int64
+		prior   map[string]tftypes.Value
+		want    AclResourceModel
+	}{
+		{"version 0", 0, map[string]tftypes.Value{
+			"id":                   tftypes.NewValue(tftypes.String, "app/TOPIC#team/orders#LITERAL#*#READ#ALLOW"),
+			"service_account_name": tftypes.NewValue(tftypes.String, "app"),
+		}, AclResourceModel{
+			ID:                 types.StringValue("lkc-123abc/app/TOPIC#team%2Forders#LITERAL#*#READ#ALLOW"),
+			ServiceAccountName: types.StringValue("app"),
+			Principal:          types.StringNull(),
+			WaitForPropagation: types.StringNull(),
+			AdoptExisting:      types.BoolNull(),
+		}},
+		{"version 1", 1, map[string]tftypes.Value{
+			"id":                   tftypes.NewValue(tftypes.String, "lkc-123abc/app/TOPIC#team/orders#LITERAL#*#READ#ALLOW"),
+			"service_account_name": tftypes.NewValue(tftypes.String, "app"),
+			"principal":            tftypes.NewValue(tftypes.String, "User:sa-123abc"),
+			"wait_for_propagation": tftypes.NewValue(tftypes.String, "1m"),
+		}, AclResourceModel{
+			ID:                 types.StringValue("lkc-123abc/User:sa-123abc/TOPIC#team%2Forders#LITERAL#*#READ#ALLOW"),
+			ServiceAccountName: types.StringValue("app"),
+			Principal:          types.StringValue("User:sa-123abc"),
+			WaitForPropagation: types.StringValue("1m"),
+			AdoptExisting:      types.BoolNull(),
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			upgrader := upgraders[test.version]
+			for name, value := range map[string]tftypes.Value{
+				"rest_endpoint": tftypes.NewValue(tftypes.String, "https://pkc-00000.confluent.cloud:443"),
+				"cluster_id":    tftypes.NewValue(tftypes.String, "lkc-123abc"),
+				"resource_type": tftypes.NewValue(tftypes.String, "TOPIC"),
+				"resource_name": tftypes.NewValue(tftypes.String, "team/orders"),
+				"pattern_type":  tftypes.NewValue(tftypes.String, "LITERAL"),
+				"host":          tftypes.NewValue(tftypes.String, "*"),
+				"operation":     tftypes.NewValue(tftypes.String, "READ"),
+				"permission":    tftypes.NewValue(tftypes.String, "ALLOW"),
+			} {
+				test.prior[name] = value
+			}
+			req := fwresource.UpgradeStateRequest{State: &tfsdk.State{
+				Schema: *upgrader.PriorSchema,
+				Raw:    testAclObjectValue(upgrader.PriorSchema.Type().TerraformType(context.Background()), test.prior),
+			}}
+			resp := fwresource.UpgradeStateResponse{State: testAclEmptyState(r)}
+			upgrader.StateUpgrader(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			var got AclResourceModel
+			if diags := resp.State.Get(context.Background(), &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			test.want.RestEndpoint = types.StringValue("https://pkc-00000.confluent.cloud:443")
+			test.want.ClusterId = types.StringValue("lkc-123abc")
+			test.want.ResourceType = types.StringValue("TOPIC")
+			test.want.ResourceName = types.StringValue("team/orders")
+			test.want.PatternType = types.StringValue("LITERAL")
+			test.want.Host = types.StringValue("*")
+			test.want.Operation = types.StringValue("READ")
+			test.want.Permission = types.StringValue("ALLOW")
+			if got != test.want {
+				t.Errorf("got state %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAclMoveState(t *testing.T) {
+	r := &AclResource{}
+	mover := r.MoveState(context.Background())[0]
+	source := `{
+		"kafka_cluster": [{"id": "lkc-123abc"}],
+		"rest_endpoint": "https://pkc-00000.confluent.cloud:443",
+		"credentials": [{"key": "key", "secret": "secret"}],
+		"resource_type": "TOPIC",
+		"resource_name": "orders#v1",
+		"pattern_type": "PREFIXED",
+		"principal": "User:sa-123abc",
+		"host": "*",
+		"operation": "READ",
+		"permission": "ALLOW"
+	}`
+	tests := []struct {
+		name          string
+		address       string
+		typeName      string
+		json          string
+		wantError     string
+		wantNullState bool
+	}{
+		{"confluent_kafka_acl", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_acl", source, "", false},
+		{"other resource type", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_topic", source, "", true},
+		{"other provider", "registry.terraform.io/hashicorp/aws", "confluent_kafka_acl", source, "", true},
+		{"no rest endpoint", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_acl",
+			strings.Replace(source, `"https://pkc-00000.confluent.cloud:443"`, `""`, 1), "Source state has no rest_endpoint", false},
+		{"no cluster", "registry.terraform.io/confluentinc/confluent", "confluent_kafka_acl",
+			strings.Replace(source, `[{"id": "lkc-123abc"}]`, `[]`, 1), "Source state has no kafka_cluster id", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := fwresource.MoveStateRequest{
+				SourceProviderAddress: test.address,
+				SourceTypeName:        test.typeName,
+				SourceRawState:        &tfprotov6.RawState{JSON: []byte(test.json)},
+			}
+			resp := fwresource.MoveStateResponse{TargetState: testAclEmptyState(r)}
+			mover.StateMover(context.Background(), req, &resp)
+			if test.wantError != "" {
+				if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), test.wantError) {
+					t.Errorf("got diagnostics %v, want error %q", resp.Diagnostics, test.wantError)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if test.wantNullState {
+				if !resp.TargetState.Raw.IsNull() {
+					t.Errorf("got state %v, want none", resp.TargetState.Raw)
+				}
+				return
+			}
+			var got AclResourceModel
+			if diags := resp.TargetState.Get(context.Background(), &got); diags.HasError() {
+				t.Fatal(diags)
+			}
+			want := AclResourceModel{
+				ID:                 types.StringValue("lkc-123abc/User:sa-123abc/TOPIC#orders%23v1#PREFIXED#*#READ#ALLOW"),
+				RestEndpoint:       types.StringValue("https://pkc-00000.confluent.cloud:443"),
+				ServiceAccountName: types.StringNull(),
+				Principal:          types.StringValue("User:sa-123abc"),
+				ClusterId:          types.StringValue("lkc-123abc"),
+				ResourceType:       types.StringValue("TOPIC"),
+				ResourceName:       types.StringValue("orders#v1"),
+				PatternType:        types.StringValue("PREFIXED"),
+				Host:               types.StringValue("*"),
+				Operation:          types.StringValue("READ"),
+				Permission:         types.StringValue("ALLOW"),
+				WaitForPropagation: types.StringNull(),
+				AdoptExisting:      types.BoolNull(),
+			}
+			if got != want {
+				t.Errorf("got state %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+// testAclEmptyState is a null state of the current ACL schema, as passed to upgraders and movers
+func testAclEmptyState(r *AclResource) tfsdk.State {
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &schemaResp)
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+// testAclObjectValue builds an object of the given type, with null attributes for the missing values
+func testAclObjectValue(objectType tftypes.Type, values map[string]tftypes.Value) tftypes.Value {
+	attributes := make(map[string]tftypes.Value)
+	for name, attributeType := range objectType.(tftypes.Object).AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		}
+	}
+	return tftypes.NewValue(objectType, attributes)
+}
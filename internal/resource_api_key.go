@@ -2,9 +2,12 @@ package internal
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"terraform-provider-confluentacl/internal/client"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -13,8 +16,9 @@ import (
 )
 
 var (
-	_ resource.Resource              = &ApiKeyResource{}
-	_ resource.ResourceWithConfigure = &ApiKeyResource{}
+	_ resource.Resource               = &ApiKeyResource{}
+	_ resource.ResourceWithConfigure  = &ApiKeyResource{}
+	_ resource.ResourceWithModifyPlan = &ApiKeyResource{}
 )
 
 type ApiKeyResource struct {
@@ -43,7 +47,7 @@ func (r *ApiKeyResource) Configure(_ context.Context, req resource.ConfigureRequ
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(*client.Client)
+	r.client = req.ProviderData.(*providerData).client
 }
 
 func (r *ApiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -55,9 +59,9 @@ func (r *ApiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			// Replaced by ModifyPlan unless the new name is the one of the owner, after renaming the service account
 			"service_account_name": schema.StringAttribute{
-				Required:      true,
-				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Required: true,
 			},
 			"environment_id": schema.StringAttribute{
 				Required:      true,
@@ -86,6 +90,47 @@ func (r *ApiKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 	}
 }
 
+// ModifyPlan warns when the service account doesn't exist, and replaces the api key when service_account_name names
+// another service account than its owner
+func (r *ApiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var serviceAccountName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("service_account_name"), &serviceAccountName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkPlannedServiceAccount(r.client, serviceAccountName, &resp.Diagnostics)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var stateServiceAccountName, apiKey types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("service_account_name"), &stateServiceAccountName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("api_key"), &apiKey)...)
+	if resp.Diagnostics.HasError() || serviceAccountName.Equal(stateServiceAccountName) {
+		return
+	}
+	if !r.isApiKeyOwner(serviceAccountName, apiKey) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("service_account_name"))
+	}
+}
+
+// isApiKeyOwner tells whether the named service account owns the api key, as when it was renamed since the api key
+// was created
+func (r *ApiKeyResource) isApiKeyOwner(serviceAccountName, apiKey types.String) bool {
+	if r.client == nil || serviceAccountName.IsUnknown() {
+		return false
+	}
+	key, err := r.client.ReadApiKey(apiKey.ValueString())
+	if err != nil || key == nil {
+		return false
+	}
+	serviceAccount, err := r.client.GetServiceAccount(serviceAccountName.ValueString())
+	return err == nil && serviceAccount != nil && serviceAccount.Id == key.Spec.Owner.ID
+}
+
 func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan ApiKeyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -96,12 +141,14 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	userId, err := r.client.GetSaNumericId(plan.ServiceAccountName.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		resp.Diagnostics.AddError("Failed to resolve service account", err.Error())
 	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	apiKey, err := r.client.CreateApiKey(userId, plan.EnvironmentId.ValueString(), plan.ResourceId.ValueString(), plan.Description.ValueString())
+	// The id of the created key is recorded as the resource id
+	ctx = client.WithAuditSource(ctx, "confluentacl_api_key", "")
+	apiKey, err := r.client.CreateApiKey(ctx, userId, plan.EnvironmentId.ValueString(), plan.ResourceId.ValueString(), plan.Description.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create Api Key", err.Error())
 	}
@@ -134,7 +181,8 @@ func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 	if apiKey == nil {
-		state.ID = types.StringNull()
+		r.warnApiKeyDeleted(&state, &resp.Diagnostics)
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -145,8 +193,48 @@ func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		readDescription = currentDescription
 	}
 
-	state.Description = types.StringValue(readDescription)
+	// An unset description stays null instead of drifting to ""
+	if !state.Description.IsNull() || readDescription != "" {
+		state.Description = types.StringValue(readDescription)
+	}
 	state.ResourceId = types.StringValue(apiKey.Spec.Resource.ID)
+	if apiKey.Spec.Resource.Environment != "" {
+		state.EnvironmentId = types.StringValue(apiKey.Spec.Resource.Environment)
+	}
+	owner, err := r.client.GetServiceAccountById(apiKey.Spec.Owner.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list service accounts", err.Error())
+		return
+	}
+	// The configured name is kept, as changing it in the state would replace the api key on the next plan
+	if owner != nil && owner.ServiceName != state.ServiceAccountName.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("service_account_name"), "Service account renamed",
+			fmt.Sprintf("Service account %s owning api key %s was renamed from %s to %s outside terraform. Set service_account_name to %s, which keeps the api key.",
+				owner.Id, state.ApiKey.ValueString(), state.ServiceAccountName.ValueString(), owner.ServiceName, owner.ServiceName))
+	}
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// warnApiKeyDeleted explains why an api key disappeared: deleting a service account deletes its api keys
+func (r *ApiKeyResource) warnApiKeyDeleted(state *ApiKeyResourceModel, diagnostics *diag.Diagnostics) {
+	serviceAccount, err := r.client.GetServiceAccount(state.ServiceAccountName.ValueString())
+	if err == nil && serviceAccount == nil {
+		diagnostics.AddWarning(
+			"Service account deleted outside terraform",
+			fmt.Sprintf("Service account %s of api key %s no longer exists, along with its api keys. The resource is removed from the state and will be created again if still configured.",
+				state.ServiceAccountName.ValueString(), state.ApiKey.ValueString()),
+		)
+		return
+	}
+	diagnostics.AddWarning(
+		"Api key deleted outside terraform",
+		fmt.Sprintf("Api key %s of service account %s no longer exists. The resource is removed from the state and will be created again with a new secret if still configured.",
+			state.ApiKey.ValueString(), state.ServiceAccountName.ValueString()),
+	)
 }
 
 func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -160,7 +248,8 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if description == "" {
 		description = "--" // Description cannot be set to empty, the request doesn't work even in the UI
 	}
-	err := r.client.UpdateApiKey(plan.ID.ValueString(), description, plan.EnvironmentId.ValueString(), plan.ResourceId.ValueString())
+	ctx = client.WithAuditSource(ctx, "confluentacl_api_key", plan.ID.ValueString())
+	err := r.client.UpdateApiKey(ctx, plan.ID.ValueString(), description, plan.EnvironmentId.ValueString(), plan.ResourceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update api key", err.Error())
 		if resp.Diagnostics.HasError() {
@@ -183,7 +272,8 @@ func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteApiKey(state.ID.ValueString(), state.EnvironmentId.ValueString(), state.ResourceId.ValueString())
+	ctx = client.WithAuditSource(ctx, "confluentacl_api_key", state.ID.ValueString())
+	err := r.client.DeleteApiKey(ctx, state.ID.ValueString(), state.EnvironmentId.ValueString(), state.ResourceId.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete api key", err.Error())
 		if resp.Diagnostics.HasError() {
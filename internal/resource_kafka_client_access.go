@@ -0,0 +1,353 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	kafkaClientRoleProducer              = "producer"
+	kafkaClientRoleConsumer              = "consumer"
+	kafkaClientRoleTransactionalProducer = "transactional_producer"
+	kafkaClientRoleStreamsApp            = "streams_app"
+)
+
+var (
+	_ resource.Resource                   = &KafkaClientAccessResource{}
+	_ resource.ResourceWithConfigure      = &KafkaClientAccessResource{}
+	_ resource.ResourceWithValidateConfig = &KafkaClientAccessResource{}
+	_ resource.ResourceWithModifyPlan     = &KafkaClientAccessResource{}
+)
+
+// KafkaClientAccessResource manages the standard bundle of ACLs a kafka client needs for its role
+type KafkaClientAccessResource struct {
+	client    *client.Client
+	aclPolicy *aclPolicy
+}
+
+type KafkaClientAccessResourceModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	RestEndpoint          types.String   `tfsdk:"rest_endpoint"`
+	ClusterId             types.String   `tfsdk:"cluster_id"`
+	ServiceAccountName    types.String   `tfsdk:"service_account_name"`
+	Principal             types.String   `tfsdk:"principal"`
+	Role                  types.String   `tfsdk:"role"`
+	TopicPrefix           types.String   `tfsdk:"topic_prefix"`
+	GroupPrefix           types.String   `tfsdk:"group_prefix"`
+	TransactionalIdPrefix types.String   `tfsdk:"transactional_id_prefix"`
+	Acls                  []AclRuleModel `tfsdk:"acl"`
+	WaitForPropagation    types.String   `tfsdk:"wait_for_propagation"`
+	AdoptExisting         types.Bool     `tfsdk:"adopt_existing"`
+}
+
+func NewKafkaClientAccessResource() resource.Resource {
+	return &KafkaClientAccessResource{}
+}
+
+func (r *KafkaClientAccessResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kafka_client_access"
+}
+
+func (r *KafkaClientAccessResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
+}
+
+func (r *KafkaClientAccessResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+			},
+			"role": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(kafkaClientRoleProducer, kafkaClientRoleConsumer, kafkaClientRoleTransactionalProducer, kafkaClientRoleStreamsApp),
+				},
+			},
+			// An empty prefix would grant the role on every resource of the cluster
+			"topic_prefix": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"group_prefix": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"transactional_id_prefix": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"acl": schema.SetNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: aclRuleComputedAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig requires the prefixes used by the role, and rejects the ones it doesn't use
+func (r *KafkaClientAccessResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config KafkaClientAccessResourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || config.Role.IsUnknown() || config.Role.IsNull() {
+		return
+	}
+
+	role := config.Role.ValueString()
+	usesGroups := role == kafkaClientRoleConsumer || role == kafkaClientRoleStreamsApp
+	usesTransactionalIds := role == kafkaClientRoleTransactionalProducer
+	for _, prefix := range []struct {
+		attribute string
+		value     types.String
+		used      bool
+	}{
+		{"group_prefix", config.GroupPrefix, usesGroups},
+		{"transactional_id_prefix", config.TransactionalIdPrefix, usesTransactionalIds},
+	} {
+		if prefix.used && prefix.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(prefix.attribute), "Missing "+prefix.attribute, fmt.Sprintf("Role %s requires %s", role, prefix.attribute))
+		}
+		if !prefix.used && !prefix.value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root(prefix.attribute), "Unused "+prefix.attribute, fmt.Sprintf("Role %s doesn't use %s", role, prefix.attribute))
+		}
+	}
+}
+
+// ModifyPlan checks the service account and cluster exist, expands the role into its ACLs, so the ACLs being added and
+// removed show up in the plan, and enforces the acl_policy of the provider on them. High-risk changes are warned about.
+func (r *KafkaClientAccessResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		warnHighRiskAclRuleChanges(ctx, req, req.Plan, "acl", &resp.Diagnostics)
+		return
+	}
+	checkPlannedAclTarget(ctx, r.client, req.Plan, &resp.Diagnostics)
+	// The acl attribute may be unknown, so the plan can't be read into the model
+	var role, topicPrefix, groupPrefix, transactionalIdPrefix types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("role"), &role)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("topic_prefix"), &topicPrefix)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("group_prefix"), &groupPrefix)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("transactional_id_prefix"), &transactionalIdPrefix)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if role.IsUnknown() || topicPrefix.IsUnknown() || groupPrefix.IsUnknown() || transactionalIdPrefix.IsUnknown() {
+		return
+	}
+
+	acls := kafkaClientAccessRules(role.ValueString(), topicPrefix.ValueString(), groupPrefix.ValueString(), transactionalIdPrefix.ValueString())
+	diags := resp.Plan.SetAttribute(ctx, path.Root("acl"), acls)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	warnHighRiskAclRuleChanges(ctx, req, resp.Plan, "acl", &resp.Diagnostics)
+	r.aclPolicy.checkPlannedAclRules(ctx, resp.Plan, "acl", &resp.Diagnostics)
+}
+
+func (r *KafkaClientAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan KafkaClientAccessResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, plan.ServiceAccountName, plan.Principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	plan.Principal = types.StringValue(principal)
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.ClusterId.ValueString(), principal))
+	plan.Acls = kafkaClientAccessRules(plan.Role.ValueString(), plan.TopicPrefix.ValueString(), plan.GroupPrefix.ValueString(), plan.TransactionalIdPrefix.ValueString())
+	r.applyAcls(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err = waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Acls, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *KafkaClientAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state KafkaClientAccessResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if errors.Is(err, errServiceAccountNotFound) {
+		removeResourceOfDeletedServiceAccount(ctx, resp, "kafka client access "+state.ID.ValueString(), state.ServiceAccountName.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	state.Principal = types.StringValue(principal)
+	aclsFound, err := listPrincipalAcls(r.client, state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	// ACLs of the bundle deleted outside terraform are dropped from the state, so they're planned to be created again
+	state.Acls = aclRulesFromAcls(filterAclsByRules(aclsFound, state.Acls))
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *KafkaClientAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state KafkaClientAccessResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Acls = kafkaClientAccessRules(plan.Role.ValueString(), plan.TopicPrefix.ValueString(), plan.GroupPrefix.ValueString(), plan.TransactionalIdPrefix.ValueString())
+	r.applyAcls(ctx, &plan, state.Acls, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Acls, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *KafkaClientAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state KafkaClientAccessResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previousAcls := state.Acls
+	state.Acls = nil
+	r.applyAcls(ctx, &state, previousAcls, &resp.Diagnostics)
+}
+
+func (r *KafkaClientAccessResource) applyAcls(ctx context.Context, model *KafkaClientAccessResourceModel, previousAcls []AclRuleModel, diagnostics *diag.Diagnostics) {
+	checkExistingAclRules(r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), model.Principal.ValueString(), model.Acls, previousAcls, model.AdoptExisting, diagnostics)
+	if diagnostics.HasError() {
+		return
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_kafka_client_access", model.ID.ValueString())
+	err := applyAclRuleChanges(ctx, r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), model.Principal.ValueString(), model.Acls, previousAcls)
+	if err != nil {
+		diagnostics.AddError("Failed to apply ACLs of principal "+model.Principal.ValueString(), err.Error())
+	}
+}
+
+// kafkaClientAccessRules expands a client role into the ACLs kafka clients of that role need.
+// Streams apps use the group prefix as their application.id, which also prefixes their internal topics and
+// transactional ids.
+func kafkaClientAccessRules(role, topicPrefix, groupPrefix, transactionalIdPrefix string) []AclRuleModel {
+	var rules []AclRuleModel
+	seen := make(map[string]bool)
+	addRules := func(resourceType, resourceName, patternType string, operations ...string) {
+		for _, operation := range operations {
+			rule := newAclRule(resourceType, resourceName, patternType, operation, "ALLOW")
+			// Prefixes can overlap, e.g. streams apps using the same prefix for topics and application.id
+			if !seen[rule.key()] {
+				seen[rule.key()] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	switch role {
+	case kafkaClientRoleProducer:
+		addRules("TOPIC", topicPrefix, "PREFIXED", "WRITE", "DESCRIBE")
+		addRules("CLUSTER", clusterResourceName, "LITERAL", "IDEMPOTENT_WRITE")
+	case kafkaClientRoleConsumer:
+		addRules("TOPIC", topicPrefix, "PREFIXED", "READ", "DESCRIBE")
+		addRules("GROUP", groupPrefix, "PREFIXED", "READ", "DESCRIBE")
+	case kafkaClientRoleTransactionalProducer:
+		addRules("TOPIC", topicPrefix, "PREFIXED", "WRITE", "DESCRIBE")
+		addRules("TRANSACTIONAL_ID", transactionalIdPrefix, "PREFIXED", "WRITE", "DESCRIBE")
+		addRules("CLUSTER", clusterResourceName, "LITERAL", "IDEMPOTENT_WRITE")
+	case kafkaClientRoleStreamsApp:
+		addRules("TOPIC", topicPrefix, "PREFIXED", "READ", "WRITE", "DESCRIBE")
+		addRules("TOPIC", groupPrefix, "PREFIXED", "CREATE", "DELETE", "READ", "WRITE", "DESCRIBE", "DESCRIBE_CONFIGS")
+		addRules("GROUP", groupPrefix, "PREFIXED", "READ", "DESCRIBE")
+		addRules("TRANSACTIONAL_ID", groupPrefix, "PREFIXED", "WRITE", "DESCRIBE")
+		addRules("CLUSTER", clusterResourceName, "LITERAL", "IDEMPOTENT_WRITE")
+	}
+	return rules
+}
@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestKafkaClientAccessCreation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKafkaClientAccessConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "producer", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_kafka_client_access.example", "id"),
+					resource.TestCheckResourceAttr("confluentacl_kafka_client_access.example", "acl.#", "3"),
+				),
+			},
+			{
+				Config: testAccKafkaClientAccessConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "consumer", `group_prefix = "terraform-provider-confluentacl-test"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_kafka_client_access.example", "acl.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKafkaClientAccessConfig(saName, clusterId, restEndpoint, role, extraAttributes string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_kafka_client_access" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			role         = "%s"
+			topic_prefix = "terraform-provider-confluentacl-test"
+			%s
+		}
+		`, saName, clusterId, restEndpoint, role, extraAttributes)
+}
+
+func TestKafkaClientAccessRules(t *testing.T) {
+	tests := []struct {
+		role string
+		want []string
+	}{
+		{kafkaClientRoleProducer, []string{
+			"TOPIC#orders.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#orders.#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+		}},
+		{kafkaClientRoleConsumer, []string{
+			"TOPIC#orders.#PREFIXED#*#READ#ALLOW",
+			"TOPIC#orders.#PREFIXED#*#DESCRIBE#ALLOW",
+			"GROUP#orders-app#PREFIXED#*#READ#ALLOW",
+			"GROUP#orders-app#PREFIXED#*#DESCRIBE#ALLOW",
+		}},
+		{kafkaClientRoleTransactionalProducer, []string{
+			"TOPIC#orders.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#orders.#PREFIXED#*#DESCRIBE#ALLOW",
+			"TRANSACTIONAL_ID#orders-tx#PREFIXED#*#WRITE#ALLOW",
+			"TRANSACTIONAL_ID#orders-tx#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+		}},
+		{kafkaClientRoleStreamsApp, []string{
+			"TOPIC#orders.#PREFIXED#*#READ#ALLOW",
+			"TOPIC#orders.#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#orders.#PREFIXED#*#DESCRIBE#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#CREATE#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#DELETE#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#READ#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#WRITE#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#DESCRIBE#ALLOW",
+			"TOPIC#orders-app#PREFIXED#*#DESCRIBE_CONFIGS#ALLOW",
+			"GROUP#orders-app#PREFIXED#*#READ#ALLOW",
+			"GROUP#orders-app#PREFIXED#*#DESCRIBE#ALLOW",
+			"TRANSACTIONAL_ID#orders-app#PREFIXED#*#WRITE#ALLOW",
+			"TRANSACTIONAL_ID#orders-app#PREFIXED#*#DESCRIBE#ALLOW",
+			"CLUSTER#kafka-cluster#LITERAL#*#IDEMPOTENT_WRITE#ALLOW",
+		}},
+		{"unknown", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.role, func(t *testing.T) {
+			var got []string
+			for _, rule := range kafkaClientAccessRules(test.role, "orders.", "orders-app", "orders-tx") {
+				got = append(got, rule.key())
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("got ACLs\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestKafkaClientAccessRulesOverlappingPrefixes(t *testing.T) {
+	// A streams app using its application.id as topic prefix gets each ACL once
+	rules := kafkaClientAccessRules(kafkaClientRoleStreamsApp, "orders-app", "orders-app", "")
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if seen[rule.key()] {
+			t.Errorf("duplicate ACL %s", rule.key())
+		}
+		seen[rule.key()] = true
+	}
+	if len(rules) != 11 {
+		t.Errorf("got %d ACLs, want 11", len(rules))
+	}
+}
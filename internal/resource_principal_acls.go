@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                     = &PrincipalAclsResource{}
+	_ resource.ResourceWithConfigure        = &PrincipalAclsResource{}
+	_ resource.ResourceWithConfigValidators = &PrincipalAclsResource{}
+	_ resource.ResourceWithModifyPlan       = &PrincipalAclsResource{}
+)
+
+// PrincipalAclsResource owns every ACL of a principal in a cluster. ACLs of the principal that aren't in the
+// configuration are reported as drift and deleted on apply.
+type PrincipalAclsResource struct {
+	client    *client.Client
+	aclPolicy *aclPolicy
+}
+
+type PrincipalAclsResourceModel struct {
+	ID                 types.String   `tfsdk:"id"`
+	RestEndpoint       types.String   `tfsdk:"rest_endpoint"`
+	ClusterId          types.String   `tfsdk:"cluster_id"`
+	ServiceAccountName types.String   `tfsdk:"service_account_name"`
+	Principal          types.String   `tfsdk:"principal"`
+	Acls               []AclRuleModel `tfsdk:"acl"`
+	WaitForPropagation types.String   `tfsdk:"wait_for_propagation"`
+}
+
+func NewPrincipalAclsResource() resource.Resource {
+	return &PrincipalAclsResource{}
+}
+
+func (r *PrincipalAclsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_principal_acls"
+}
+
+func (r *PrincipalAclsResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
+}
+
+func (r *PrincipalAclsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+			"acl": schema.SetNestedAttribute{
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: aclRuleNestedAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PrincipalAclsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{aclRulesValidator{attribute: "acl"}}
+}
+
+// ModifyPlan warns about high-risk changes, checks the service account and cluster exist, and enforces the acl_policy
+// of the provider
+func (r *PrincipalAclsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnHighRiskAclRuleChanges(ctx, req, req.Plan, "acl", &resp.Diagnostics)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	checkPlannedAclTarget(ctx, r.client, req.Plan, &resp.Diagnostics)
+	r.aclPolicy.checkPlannedAclRules(ctx, req.Plan, "acl", &resp.Diagnostics)
+}
+
+func (r *PrincipalAclsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PrincipalAclsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, plan.ServiceAccountName, plan.Principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	plan.Principal = types.StringValue(principal)
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s", plan.ClusterId.ValueString(), principal))
+	r.applyAcls(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err = waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Acls, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *PrincipalAclsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PrincipalAclsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if errors.Is(err, errServiceAccountNotFound) {
+		removeResourceOfDeletedServiceAccount(ctx, resp, "principal ACLs "+state.ID.ValueString(), state.ServiceAccountName.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	state.Principal = types.StringValue(principal)
+	aclsFound, err := listPrincipalAcls(r.client, state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	// Every ACL of the principal is read into the state, so ACLs added outside terraform show up as drift
+	state.Acls = aclRulesFromAcls(aclsFound)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *PrincipalAclsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PrincipalAclsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyAcls(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), plan.Principal.ValueString(), plan.Acls, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACLs applied but not propagated", err.Error())
+	}
+}
+
+func (r *PrincipalAclsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PrincipalAclsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Acls = nil
+	r.applyAcls(ctx, &state, &resp.Diagnostics)
+}
+
+// applyAcls makes the ACLs of the principal in the cluster exactly the ones in the model
+func (r *PrincipalAclsResource) applyAcls(ctx context.Context, model *PrincipalAclsResourceModel, diagnostics *diag.Diagnostics) {
+	principal := model.Principal.ValueString()
+	aclsFound, err := listPrincipalAcls(r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), principal)
+	if err != nil {
+		diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_principal_acls", model.ID.ValueString())
+	err = reconcileAcls(ctx, r.client, model.RestEndpoint.ValueString(), model.ClusterId.ValueString(), principal, model.Acls, aclsFound)
+	if err != nil {
+		diagnostics.AddError("Failed to apply ACLs of principal "+principal, err.Error())
+	}
+}
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestPrincipalAclsCreation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPrincipalAclsConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "READ"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_principal_acls.example", "id"),
+					resource.TestCheckResourceAttrSet("confluentacl_principal_acls.example", "principal"),
+					resource.TestCheckResourceAttr("confluentacl_principal_acls.example", "acl.#", "2"),
+				),
+			},
+			{
+				Config: testAccPrincipalAclsConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, "WRITE"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_principal_acls.example", "acl.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPrincipalAclsConfig(saName, clusterId, restEndpoint, topicOperation string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_principal_acls" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			acl = [
+				{
+					resource_type = "TOPIC"
+					resource_name = "terraform-provider-confluentacl-test"
+					pattern_type  = "LITERAL"
+					host          = "*"
+					operation     = "%s"
+					permission    = "ALLOW"
+				},
+				{
+					resource_type = "GROUP"
+					resource_name = "terraform-provider-confluentacl-test"
+					pattern_type  = "PREFIXED"
+					host          = "*"
+					operation     = "READ"
+					permission    = "ALLOW"
+				},
+			]
+		}
+		`, saName, clusterId, restEndpoint, topicOperation)
+}
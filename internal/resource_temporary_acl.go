@@ -0,0 +1,476 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = &TemporaryAclResource{}
+	_ resource.ResourceWithConfigure        = &TemporaryAclResource{}
+	_ resource.ResourceWithConfigValidators = &TemporaryAclResource{}
+	_ resource.ResourceWithModifyPlan       = &TemporaryAclResource{}
+)
+
+// TemporaryAclResource is an ACL reported by refresh once it expires, and revoked by the next apply with
+// sweep_expired_temporary_acls. Renewing it, by changing expires_at or duration, replaces it.
+type TemporaryAclResource struct {
+	client       *client.Client
+	aclPolicy    *aclPolicy
+	sweepExpired bool
+}
+
+type TemporaryAclResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	RestEndpoint       types.String `tfsdk:"rest_endpoint"`
+	ServiceAccountName types.String `tfsdk:"service_account_name"`
+	Principal          types.String `tfsdk:"principal"`
+	ClusterId          types.String `tfsdk:"cluster_id"`
+	ResourceType       types.String `tfsdk:"resource_type"`
+	ResourceName       types.String `tfsdk:"resource_name"`
+	PatternType        types.String `tfsdk:"pattern_type"`
+	Host               types.String `tfsdk:"host"`
+	Operation          types.String `tfsdk:"operation"`
+	Permission         types.String `tfsdk:"permission"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+	Duration           types.String `tfsdk:"duration"`
+	Revoked            types.Bool   `tfsdk:"revoked"`
+	WaitForPropagation types.String `tfsdk:"wait_for_propagation"`
+}
+
+// aclModel returns the ACL attributes of the model, to share the id and lookups of confluentacl_acl
+func (m *TemporaryAclResourceModel) aclModel() *AclResourceModel {
+	return &AclResourceModel{
+		ID:                 m.ID,
+		RestEndpoint:       m.RestEndpoint,
+		ServiceAccountName: m.ServiceAccountName,
+		Principal:          m.Principal,
+		ClusterId:          m.ClusterId,
+		ResourceType:       m.ResourceType,
+		ResourceName:       m.ResourceName,
+		PatternType:        m.PatternType,
+		Host:               m.Host,
+		Operation:          m.Operation,
+		Permission:         m.Permission,
+		WaitForPropagation: m.WaitForPropagation,
+	}
+}
+
+// expired tells whether the expiry time is reached. Unknown or invalid expiry times aren't expired.
+func (m *TemporaryAclResourceModel) expired(now time.Time) bool {
+	if m.ExpiresAt.IsNull() || m.ExpiresAt.IsUnknown() {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, m.ExpiresAt.ValueString())
+	return err == nil && !now.Before(expiresAt)
+}
+
+// replaces tells whether the planned model changes attributes requiring the replacement of the stored one
+func (m *TemporaryAclResourceModel) replaces(state *TemporaryAclResourceModel) bool {
+	for _, values := range [][2]types.String{
+		{m.ServiceAccountName, state.ServiceAccountName},
+		{m.ClusterId, state.ClusterId},
+		{m.RestEndpoint, state.RestEndpoint},
+		{m.ResourceType, state.ResourceType},
+		{m.ResourceName, state.ResourceName},
+		{m.PatternType, state.PatternType},
+		{m.Host, state.Host},
+		{m.Operation, state.Operation},
+		{m.Permission, state.Permission},
+		{m.Duration, state.Duration},
+		{m.ExpiresAt, state.ExpiresAt},
+	} {
+		if !values[0].Equal(values[1]) {
+			return true
+		}
+	}
+	return m.ServiceAccountName.IsNull() && !m.Principal.Equal(state.Principal)
+}
+
+func NewTemporaryAclResource() resource.Resource {
+	return &TemporaryAclResource{}
+}
+
+func (r *TemporaryAclResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_temporary_acl"
+}
+
+func (r *TemporaryAclResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data := req.ProviderData.(*providerData)
+	r.client = data.client
+	r.aclPolicy = data.aclPolicy
+	r.sweepExpired = data.sweepExpiredTemporaryAcls
+}
+
+func (r *TemporaryAclResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"service_account_name": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("principal")),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						func(_ context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.ConfigValue.IsNull()
+						},
+						"Changing a configured principal requires replacing the ACL", "Changing a configured principal requires replacing the ACL",
+					),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(principalRegex, principalValidationMessage),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^lkc-.+`), "Value must start with lkc-"),
+				},
+			},
+			"rest_endpoint": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"resource_type": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.OneOf(aclResourceTypes...)},
+			},
+			"resource_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"pattern_type": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.OneOf(aclPatternTypes...)},
+			},
+			"host": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"operation": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.OneOf(aclOperations...)},
+			},
+			"permission": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{stringvalidator.OneOf(aclPermissions...)},
+			},
+			"expires_at": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("duration")),
+					rfc3339Validator{},
+				},
+			},
+			"duration": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(durationRegex, "Value must be a duration, e.g. 30m, 4h or 1h30m"),
+				},
+			},
+			"revoked": schema.BoolAttribute{
+				Computed: true,
+			},
+			"wait_for_propagation": waitForPropagationAttribute(),
+		},
+	}
+}
+
+func (r *TemporaryAclResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{aclValidator{}}
+}
+
+// ModifyPlan warns about high-risk changes, checks the service account and cluster exist, enforces the acl_policy of
+// the provider, and plans the revocation of expired ACLs when sweep_expired_temporary_acls is set
+func (r *TemporaryAclResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	warnHighRiskRootAclChange(ctx, req, &resp.Diagnostics)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	checkPlannedAclTarget(ctx, r.client, req.Plan, &resp.Diagnostics)
+	owner, principal := planAclOwner(ctx, req.Plan, &resp.Diagnostics)
+	rule := rootAclRule(ctx, req.Plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, problem := range r.aclPolicy.violations(owner, principal, rule) {
+		resp.Diagnostics.AddAttributeError(path.Root(problem.attribute), "ACL policy violation", problem.detail)
+	}
+
+	var plan TemporaryAclResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if req.State.Raw.IsNull() {
+		if plan.expired(time.Now()) {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Temporary ACL already expired",
+				fmt.Sprintf("expires_at %s is in the past", plan.ExpiresAt.ValueString()))
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revoked"), false)...)
+		return
+	}
+	var state TemporaryAclResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.replaces(&state) {
+		// The replacement grants the ACL again, with an expiry computed from the duration at creation
+		if !plan.Duration.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revoked"), false)...)
+		return
+	}
+	// With sweep_expired_temporary_acls, updates revoke the ACL once expired
+	revoked := state.Revoked.ValueBool() || (r.sweepExpired && state.expired(time.Now()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revoked"), revoked)...)
+}
+
+func (r *TemporaryAclResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan TemporaryAclResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, plan.ServiceAccountName, plan.Principal)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	plan.Principal = types.StringValue(principal)
+	if !plan.Duration.IsNull() {
+		duration, err := time.ParseDuration(plan.Duration.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid duration", err.Error())
+			return
+		}
+		plan.ExpiresAt = types.StringValue(time.Now().Add(duration).UTC().Format(time.RFC3339))
+	}
+	plan.Revoked = types.BoolValue(false)
+	model := plan.aclModel()
+	plan.ID = types.StringValue(makeIdForAclModel(model))
+
+	// Revoking an ACL that existed before would remove access this resource didn't grant
+	aclsFound, _, err := findModelAcls(r.client, model)
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	if len(aclsFound) > 0 {
+		resp.Diagnostics.AddError(
+			"ACL already exists",
+			fmt.Sprintf("ACL %s already exists in cluster %s, revoking it at expiry would remove access granted elsewhere.", plan.ID.ValueString(), plan.ClusterId.ValueString()),
+		)
+		return
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_temporary_acl", plan.ID.ValueString())
+	requestBody := aclRequestFromModel(model, principal)
+	err = r.client.CreateACL(ctx, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), requestBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create ACL", err.Error())
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Temporary ACL %s granted until %s", plan.ID.ValueString(), plan.ExpiresAt.ValueString()))
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rule := aclRuleFromAcl(client.ACLListResponse{
+		ResourceType: requestBody.ResourceType,
+		ResourceName: requestBody.ResourceName,
+		PatternType:  requestBody.PatternType,
+		Host:         requestBody.Host,
+		Operation:    requestBody.Operation,
+		Permission:   requestBody.Permission,
+	})
+	err = waitForAclPropagation(ctx, r.client, plan.RestEndpoint.ValueString(), plan.ClusterId.ValueString(), principal, []AclRuleModel{rule}, plan.WaitForPropagation)
+	if err != nil {
+		resp.Diagnostics.AddError("ACL created but not propagated", err.Error())
+	}
+}
+
+func (r *TemporaryAclResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state TemporaryAclResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if errors.Is(err, errServiceAccountNotFound) {
+		removeResourceOfDeletedServiceAccount(ctx, resp, "temporary ACL "+state.ID.ValueString(), state.ServiceAccountName.ValueString())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	state.Principal = types.StringValue(principal)
+	aclsFound, _, err := findModelAcls(r.client, state.aclModel())
+	if err != nil {
+		resp.Diagnostics.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	if len(aclsFound) > 0 {
+		// A revoked ACL granted again outside terraform is revoked again by the next apply
+		state.Revoked = types.BoolValue(false)
+	}
+	expired := state.expired(time.Now())
+	switch {
+	case len(aclsFound) == 0 && (expired || state.Revoked.ValueBool()):
+		// Revoked ACLs stay in the state, so they aren't granted again
+		state.Revoked = types.BoolValue(true)
+	case len(aclsFound) == 0:
+		resp.Diagnostics.AddWarning(
+			"ACL deleted outside terraform",
+			fmt.Sprintf("Temporary ACL %s of principal %s no longer exists in cluster %s. The resource is removed from the state and will be created again if still configured.",
+				state.ID.ValueString(), principal, state.ClusterId.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	case expired && r.sweepExpired:
+		resp.Diagnostics.AddWarning(
+			"Temporary ACL expired",
+			fmt.Sprintf("Temporary ACL %s expired at %s but still grants access. The next apply revokes it, as sweep_expired_temporary_acls is set.",
+				state.ID.ValueString(), state.ExpiresAt.ValueString()),
+		)
+	case expired:
+		resp.Diagnostics.AddWarning(
+			"Temporary ACL expired",
+			fmt.Sprintf("Temporary ACL %s expired at %s but still grants access. Remove the resource to revoke it, or renew it by changing expires_at or duration.",
+				state.ID.ValueString(), state.ExpiresAt.ValueString()),
+		)
+	}
+	state.ID = types.StringValue(makeIdForAclModel(state.aclModel()))
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update revokes the expired ACLs swept by the plan, every other attribute change replaces the ACL
+func (r *TemporaryAclResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state TemporaryAclResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Revoked.ValueBool() && !state.Revoked.ValueBool() {
+		r.revoke(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	diags := resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r *TemporaryAclResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state TemporaryAclResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.Revoked.ValueBool() {
+		return
+	}
+	r.revoke(ctx, &state, &resp.Diagnostics)
+}
+
+// revoke deletes the ACL of the state, if it still exists
+func (r *TemporaryAclResource) revoke(ctx context.Context, state *TemporaryAclResourceModel, diags *diag.Diagnostics) {
+	principal, err := resolveConfiguredPrincipal(r.client, state.ServiceAccountName, state.Principal)
+	if errors.Is(err, errServiceAccountNotFound) {
+		// Deleting a service account deletes its ACLs
+		return
+	}
+	if err != nil {
+		diags.AddError("Failed to resolve ACL principal", err.Error())
+		return
+	}
+	state.Principal = types.StringValue(principal)
+	model := state.aclModel()
+	aclsFound, listedPrincipal, err := findModelAcls(r.client, model)
+	if err != nil {
+		diags.AddError("Failure to read all acls in cluster", err.Error())
+		return
+	}
+	if len(aclsFound) == 0 {
+		return
+	}
+	ctx = client.WithAuditSource(ctx, "confluentacl_temporary_acl", state.ID.ValueString())
+	err = r.client.DeleteAcl(ctx, state.RestEndpoint.ValueString(), state.ClusterId.ValueString(), aclRequestFromModel(model, listedPrincipal))
+	if err != nil {
+		diags.AddError("Failed to delete ACL", err.Error())
+	}
+}
+
+// rfc3339Validator checks timestamps are RFC 3339, e.g. 2024-05-01T18:00:00Z
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 timestamp, e.g. 2024-05-01T18:00:00Z"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid timestamp", v.Description(ctx)+": "+err.Error())
+	}
+}
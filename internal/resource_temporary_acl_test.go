@@ -0,0 +1,186 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-confluentacl/internal/client"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestTemporaryAclCreation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemporaryAclConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, `duration = "1h"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("confluentacl_temporary_acl.example", "id"),
+					resource.TestCheckResourceAttrSet("confluentacl_temporary_acl.example", "expires_at"),
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestTemporaryAclExpiry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTemporaryAclConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, `duration = "20s"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "false"),
+				),
+			},
+			{
+				// Without sweep_expired_temporary_acls, expiry is only reported
+				PreConfig: func() { time.Sleep(25 * time.Second) },
+				Config:    testAccTemporaryAclConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, `duration = "20s"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestTemporaryAclSweep(t *testing.T) {
+	config := testAccTemporaryAclSweepConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, `duration = "20s"`)
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "false"),
+				),
+			},
+			{
+				// Refreshing once expired doesn't change the cluster
+				PreConfig:    func() { time.Sleep(25 * time.Second) },
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "false"),
+				),
+			},
+			{
+				// Applying revokes the ACL without granting it again
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "true"),
+					testAccCheckTemporaryAclDeleted("confluentacl_temporary_acl.example"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				// A revoked ACL granted again outside terraform is revoked again
+				PreConfig: func() {
+					principal, err := resolveServiceAccountPrincipal(testAccClient(), testRealResource.SaName)
+					if err != nil {
+						t.Fatal(err)
+					}
+					err = testAccClient().CreateACL(context.Background(), testRealResource.RestEndpoint, testRealResource.ClusterId, &client.ACLRequest{
+						Principal:    principal,
+						ResourceType: "TOPIC",
+						ResourceName: "terraform-provider-confluentacl-temporary",
+						PatternType:  "LITERAL",
+						Host:         "*",
+						Operation:    "READ",
+						Permission:   "ALLOW",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("confluentacl_temporary_acl.example", "revoked", "true"),
+					testAccCheckTemporaryAclDeleted("confluentacl_temporary_acl.example"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckTemporaryAclDeleted checks the ACL of a temporary ACL resource no longer exists in the cluster
+func testAccCheckTemporaryAclDeleted(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		attributes := s.RootModule().Resources[name].Primary.Attributes
+		acls, err := testAccClient().ListACLs(attributes["rest_endpoint"], attributes["cluster_id"])
+		if err != nil {
+			return err
+		}
+		for _, acl := range acls {
+			if acl.ResourceType == attributes["resource_type"] && acl.ResourceName == attributes["resource_name"] && acl.Operation == attributes["operation"] {
+				return fmt.Errorf("ACL of %s wasn't deleted", name)
+			}
+		}
+		return nil
+	}
+}
+
+func TestTemporaryAclExpiredAtCreation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("0.15.4"))),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccTemporaryAclConfig(testRealResource.SaName, testRealResource.ClusterId, testRealResource.RestEndpoint, `expires_at = "2020-01-01T00:00:00Z"`),
+				ExpectError: regexp.MustCompile(`Temporary ACL already expired`),
+			},
+		},
+	})
+}
+
+func testAccTemporaryAclConfig(saName, clusterId, restEndpoint, expiry string) string {
+	return fmt.Sprintf(`
+		resource "confluentacl_temporary_acl" "example" {
+			service_account_name = "%s"
+			cluster_id           = "%s"
+			rest_endpoint        = "%s"
+
+			resource_type = "TOPIC"
+			resource_name = "terraform-provider-confluentacl-temporary"
+			pattern_type  = "LITERAL"
+			host          = "*"
+			operation     = "READ"
+			permission    = "ALLOW"
+
+			%s
+		}
+		`, saName, clusterId, restEndpoint, expiry)
+}
+
+func testAccTemporaryAclSweepConfig(saName, clusterId, restEndpoint, expiry string) string {
+	return `
+		provider "confluentacl" {
+			sweep_expired_temporary_acls = true
+		}
+		` + testAccTemporaryAclConfig(saName, clusterId, restEndpoint, expiry)
+}